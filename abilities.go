@@ -0,0 +1,494 @@
+package main
+
+// abilities are the special actions of monsters. Each ability declares when
+// it triggers, its range and cooldown in AbilitiesData, and its
+// preconditions and behaviour in the Ready and Use methods below. Kinds get
+// their abilities from MonsAbilities, so that a new monster can be made by
+// putting existing abilities together.
+
+type ability int
+
+const (
+	AbiTormentBolt ability = iota
+	AbiThrowRock
+	AbiThrowJavelin
+	AbiThrowAcid
+	AbiNixeAttraction
+	AbiVampireSpit
+	AbiThrowSpores
+	AbiAbsorbMana
+	AbiMindAttack
+	AbiInvisibility
+	AbiBlinkWhenHit
+	AbiNauseousCorpse
+	AbiExplode
+	AbiPoisonousFumes
+)
+
+const NumAbilities = int(AbiPoisonousFumes) + 1
+
+type abilityTrigger int
+
+const (
+	TriggerRanged  abilityTrigger = iota // hunting, needs a clear line of fire
+	TriggerSmiting                       // hunting, needs line of sight
+	TriggerSelf                          // hunting, no target
+	TriggerHit                           // when hit in melee by the player
+	TriggerDeath                         // when killed
+)
+
+type abilityData struct {
+	trigger      abilityTrigger
+	minDist      int // minimal distance to the player
+	cooldown     int // exhaustion time after use
+	cooldownRand int
+	delay        int // in attack delays
+}
+
+var AbilitiesData = [NumAbilities]abilityData{
+	AbiTormentBolt:    {trigger: TriggerRanged, minDist: 2, cooldown: 100, cooldownRand: 50, delay: 1},
+	AbiThrowRock:      {trigger: TriggerRanged, minDist: 2, delay: 2},
+	AbiThrowJavelin:   {trigger: TriggerRanged, minDist: 2, cooldown: 50, cooldownRand: 50, delay: 1},
+	AbiThrowAcid:      {trigger: TriggerRanged, delay: 1},
+	AbiNixeAttraction: {trigger: TriggerRanged, minDist: 2, cooldown: 100, cooldownRand: 50, delay: 1},
+	AbiVampireSpit:    {trigger: TriggerRanged, minDist: 2, cooldown: 100, cooldownRand: 50, delay: 1},
+	AbiThrowSpores:    {trigger: TriggerRanged, minDist: 2, cooldown: 100, cooldownRand: 50, delay: 1},
+	AbiAbsorbMana:     {trigger: TriggerSmiting, cooldown: 10, cooldownRand: 10, delay: 1},
+	AbiMindAttack:     {trigger: TriggerSmiting, delay: 1},
+	AbiInvisibility:   {trigger: TriggerSelf, delay: 1},
+	AbiBlinkWhenHit:   {trigger: TriggerHit},
+	AbiNauseousCorpse: {trigger: TriggerHit},
+	AbiExplode:        {trigger: TriggerDeath},
+	AbiPoisonousFumes: {trigger: TriggerDeath},
+}
+
+var MonsAbilities = map[monsterKind][]ability{
+	MonsLich:           {AbiInvisibility, AbiTormentBolt},
+	MonsCyclop:         {AbiThrowRock},
+	MonsGoblinWarrior:  {AbiThrowJavelin},
+	MonsSatowalgaPlant: {AbiThrowAcid},
+	MonsMadNixe:        {AbiNixeAttraction},
+	MonsVampire:        {AbiVampireSpit},
+	MonsTreeMushroom:   {AbiThrowSpores},
+	MonsMirrorSpecter:  {AbiAbsorbMana},
+	MonsMindCelmist:    {AbiMindAttack},
+	MonsTinyHarpy:      {AbiBlinkWhenHit},
+	MonsBrizzia:        {AbiNauseousCorpse},
+	MonsExplosiveNadre: {AbiExplode},
+	MonsAcidMound:      {AbiPoisonousFumes},
+}
+
+func (ab ability) Trigger() abilityTrigger {
+	return AbilitiesData[ab].trigger
+}
+
+func (ab ability) Delay() int {
+	return AbilitiesData[ab].delay
+}
+
+func (mk monsterKind) Abilities() []ability {
+	return MonsAbilities[mk]
+}
+
+func (mk monsterKind) HasTrigger(t abilityTrigger) bool {
+	for _, ab := range mk.Abilities() {
+		if ab.Trigger() == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (mk monsterKind) Ranged() bool {
+	return mk.HasTrigger(TriggerRanged)
+}
+
+func (mk monsterKind) Smiting() bool {
+	return mk.HasTrigger(TriggerSmiting)
+}
+
+// InRange reports whether the ability can target the player from the
+// monster position.
+func (ab ability) InRange(m *monster, g *game) bool {
+	switch ab.Trigger() {
+	case TriggerRanged, TriggerSmiting:
+		return m.Pos.Distance(g.Player.Pos) >= AbilitiesData[ab].minDist
+	}
+	return true
+}
+
+// Ready checks the specific preconditions of an ability.
+func (ab ability) Ready(m *monster, g *game) bool {
+	switch ab {
+	case AbiVampireSpit:
+		return !g.Player.HasEffect(EffNausea)
+	case AbiThrowSpores:
+		return !g.Player.HasEffect(EffLignification)
+	case AbiAbsorbMana:
+		return g.Player.MP > 0
+	case AbiMindAttack:
+		if g.Player.Pos.Distance(m.Pos) == 1 && (m.HP < m.HPmax || RandInt(2) == 0) {
+			// try to avoid melee
+			return m.SafePlacement(g) == nil
+		}
+	case AbiInvisibility:
+		return m.HP < m.HPmax/2 && !m.HasEffect(EffInvisibility) && RandInt(3) == 0
+	case AbiBlinkWhenHit:
+		return m.HP > 0
+	case AbiNauseousCorpse:
+		return RandInt(4) == 0 && !g.Player.HasEffect(EffNausea) && m.Pos.Distance(g.Player.Pos) == 1
+	}
+	return true
+}
+
+func (ab ability) Use(m *monster, g *game, ev event) {
+	switch ab {
+	case AbiTormentBolt:
+		m.TormentBolt(g, ev)
+	case AbiThrowRock:
+		m.ThrowRock(g, ev)
+	case AbiThrowJavelin:
+		m.ThrowJavelin(g, ev)
+	case AbiThrowAcid:
+		m.ThrowAcid(g, ev)
+	case AbiNixeAttraction:
+		m.NixeAttraction(g, ev)
+	case AbiVampireSpit:
+		m.VampireSpit(g, ev)
+	case AbiThrowSpores:
+		m.ThrowSpores(g, ev)
+	case AbiAbsorbMana:
+		m.AbsorbMana(g, ev)
+	case AbiMindAttack:
+		m.MindAttack(g, ev)
+	case AbiInvisibility:
+		m.AddEffect(g, EffInvisibility, EffInvisibility.Duration())
+	case AbiBlinkWhenHit:
+		m.Blink(g)
+	case AbiNauseousCorpse:
+		g.PlayerAddEffect(EffNausea, EffNausea.Duration())
+		g.Printf("%s's corpse releases some nauseating gas. You feel sick.", m.Kind.Definite(true))
+	case AbiExplode:
+		m.Explode(g, ev)
+	case AbiPoisonousFumes:
+		if m.Visible(g) {
+			g.Printf("%s releases poisonous fumes.", m.Kind.Definite(true))
+		}
+		g.Gas(m.Pos, CloudPoison, 4, ev)
+	}
+	if AbilitiesData[ab].cooldown > 0 {
+		m.ExhaustTime(g, AbilitiesData[ab].cooldown+RandInt(AbilitiesData[ab].cooldownRand))
+	}
+}
+
+// UseAbility makes a hunting monster use one of its abilities, if possible.
+// It returns true if the monster used its turn.
+func (m *monster) UseAbility(g *game, ev event) bool {
+	abilities := m.Kind.Abilities()
+	for _, ab := range abilities {
+		if ab.Trigger() == TriggerSelf && ab.Ready(m, g) {
+			ab.Use(m, g, ev)
+			ev.Renew(g, ab.Delay()*m.Kind.AttackDelay())
+			return true
+		}
+	}
+	inrange := false
+	for _, ab := range abilities {
+		switch ab.Trigger() {
+		case TriggerRanged, TriggerSmiting:
+			if ab.InRange(m, g) {
+				inrange = true
+			}
+		}
+	}
+	if !inrange {
+		return false
+	}
+	if !g.Player.LOS[m.Pos] {
+		m.FireReady = false
+		return false
+	}
+	if !m.FireReady {
+		m.FireReady = true
+		if m.Pos.Distance(g.Player.Pos) <= 3 {
+			ev.Renew(g, m.Kind.AttackDelay())
+			return true
+		}
+		return false
+	}
+	if m.HasEffect(EffExhausted) {
+		return false
+	}
+	for _, ab := range abilities {
+		switch ab.Trigger() {
+		case TriggerRanged:
+			if m.RangeBlocked(g) {
+				continue
+			}
+		case TriggerSmiting:
+		default:
+			continue
+		}
+		if !ab.InRange(m, g) || !ab.Ready(m, g) {
+			continue
+		}
+		ab.Use(m, g, ev)
+		ev.Renew(g, ab.Delay()*m.Kind.AttackDelay())
+		return true
+	}
+	return false
+}
+
+// TriggerAbilities uses the abilities of the monster that react to t.
+func (m *monster) TriggerAbilities(g *game, ev event, t abilityTrigger) {
+	for _, ab := range m.Kind.Abilities() {
+		if ab.Trigger() == t && ab.Ready(m, g) {
+			ab.Use(m, g, ev)
+		}
+	}
+}
+
+func (m *monster) TormentBolt(g *game, ev event) {
+	hit := !m.Blocked(g)
+	g.MakeNoise(9, m.Pos)
+	if hit {
+		g.MakeNoise(MagicHitNoise, g.Player.Pos)
+		damage := g.Player.HP - g.Player.HP/2
+		g.PrintfStyled("%s throws a bolt of torment at you.", logMonsterHit, m.SeenName(g, true))
+		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorCyan)
+		m.InflictDamage(g, damage, 15)
+	} else {
+		g.Printf("You block %s's bolt of torment.", m.SeenName(g, false))
+		g.BlockEffects(m)
+		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorCyan)
+	}
+}
+
+func (m *monster) ThrowRock(g *game, ev event) {
+	block := false
+	hit := true
+	evasion := RandInt(g.Player.Evasion())
+	acc := RandInt(m.Accuracy)
+	const rockdmg = 15
+	attack, clang := g.HitDamage(DmgPhysical, rockdmg, g.Player.Armor())
+	attack, evasion, clang = m.DramaticAdjustment(g, rockdmg, attack, evasion, acc, clang)
+	if 4*acc/3 <= evasion {
+		// rocks are big and do not miss so often
+		hit = false
+	} else {
+		block = m.Blocked(g)
+		hit = !block
+	}
+	if hit {
+		noise := g.HitNoise(clang)
+		g.MakeNoise(noise, g.Player.Pos)
+		var sclang string
+		if clang {
+			sclang = g.ArmourClang()
+		}
+		g.PrintfStyled("%s throws a rock at you (%d dmg).%s", logMonsterHit, m.SeenName(g, true), attack, sclang)
+		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '●', ColorMagenta)
+		oppos := g.Player.Pos
+		if m.PushPlayer(g, ev) {
+			g.TemporalWallAt(oppos, ev)
+		} else {
+			ray := g.Ray(m.Pos)
+			if len(ray) > 0 {
+				g.TemporalWallAt(ray[len(ray)-1], ev)
+			}
+		}
+		m.InflictDamage(g, attack, rockdmg)
+	} else if block {
+		g.Printf("You block %s's rock. Clang!", m.SeenIndefinite(g, false))
+		g.MakeNoise(ShieldBlockNoise, g.Player.Pos)
+		g.BlockEffects(m)
+		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '●', ColorMagenta)
+		ray := g.Ray(m.Pos)
+		if len(ray) > 0 {
+			g.TemporalWallAt(ray[len(ray)-1], ev)
+		}
+	} else {
+		g.Stats.Dodges++
+		g.Printf("You dodge %s's rock.", m.SeenIndefinite(g, false))
+		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '●', ColorMagenta)
+		dir := g.Player.Pos.Dir(m.Pos)
+		pos := g.Player.Pos.To(dir)
+		if pos.valid() {
+			mons := g.MonsterAt(pos)
+			if mons.Exists() {
+				mons.HP -= RandInt(15)
+				if mons.HP <= 0 {
+					g.HandleKill(mons, ev)
+				} else {
+					mons.Blink(g)
+					if mons.Pos != pos {
+						g.TemporalWallAt(pos, ev)
+					}
+				}
+			} else {
+				g.TemporalWallAt(pos, ev)
+			}
+		}
+	}
+}
+
+func (m *monster) VampireSpit(g *game, ev event) {
+	g.PlayerAddEffect(EffNausea, EffNausea.Duration())
+	g.Printf("%s spits at you. You feel sick.", m.SeenName(g, true))
+}
+
+func (m *monster) ThrowSpores(g *game, ev event) {
+	g.EnterLignification(ev)
+	g.Printf("%s releases spores. You feel rooted to the ground.", m.SeenName(g, true))
+}
+
+func (m *monster) ThrowJavelin(g *game, ev event) {
+	block := false
+	hit := true
+	evasion := RandInt(g.Player.Evasion())
+	acc := RandInt(m.Accuracy)
+	const jdmg = 11
+	attack, clang := g.HitDamage(DmgPhysical, jdmg, g.Player.Armor())
+	attack, evasion, clang = m.DramaticAdjustment(g, jdmg, attack, evasion, acc, clang)
+	if acc <= evasion {
+		hit = false
+	} else {
+		block = m.Blocked(g)
+		hit = !block
+	}
+	if hit {
+		noise := g.HitNoise(clang)
+		g.MakeNoise(noise, g.Player.Pos)
+		var sclang string
+		if clang {
+			sclang = g.ArmourClang()
+		}
+		g.Printf("%s throws %s at you (%d dmg).%s", m.SeenName(g, true), Indefinite("javelin", false), attack, sclang)
+		g.ui.MonsterJavelinAnimation(g.Ray(m.Pos), true)
+		m.InflictDamage(g, attack, jdmg)
+	} else if block {
+		if RandInt(3) == 0 {
+			g.Printf("You block %s's %s. Clang!", m.SeenIndefinite(g, false), "javelin")
+			g.MakeNoise(ShieldBlockNoise, g.Player.Pos)
+			g.BlockEffects(m)
+			g.ui.MonsterJavelinAnimation(g.Ray(m.Pos), false)
+		} else if !g.Player.HasEffect(EffDisabledShield) {
+			g.PlayerAddEffect(EffDisabledShield, EffDisabledShield.Duration())
+			g.Printf("%s's %s gets embedded in your shield.", m.SeenIndefinite(g, true), "javelin")
+			g.MakeNoise(ShieldBlockNoise, g.Player.Pos)
+			g.ui.MonsterJavelinAnimation(g.Ray(m.Pos), false)
+		}
+	} else {
+		g.Stats.Dodges++
+		g.Printf("You dodge %s's %s.", m.SeenIndefinite(g, false), "javelin")
+		g.ui.MonsterJavelinAnimation(g.Ray(m.Pos), false)
+	}
+}
+
+func (m *monster) ThrowAcid(g *game, ev event) {
+	block := false
+	hit := true
+	evasion := RandInt(g.Player.Evasion())
+	acc := RandInt(m.Accuracy)
+	acdmg := 12
+	attack, clang := g.HitDamage(DmgPhysical, acdmg, g.Player.Armor())
+	attack, evasion, clang = m.DramaticAdjustment(g, acdmg, attack, evasion, acc, clang)
+	if acc <= evasion {
+		hit = false
+	} else {
+		block = m.Blocked(g)
+		hit = !block
+	}
+	if hit {
+		noise := g.HitNoise(false) // no clang with acid projectiles
+		g.MakeNoise(noise, g.Player.Pos)
+		g.Printf("%s throws acid at you (%d dmg).", m.SeenName(g, true), attack)
+		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorGreen)
+		m.InflictDamage(g, attack, acdmg)
+		if RandInt(2) == 0 {
+			g.Corrosion(ev)
+			if RandInt(2) == 0 {
+				g.Confusion(ev)
+			}
+		}
+	} else if block {
+		g.Printf("You block %s's acid projectile.", m.SeenIndefinite(g, false))
+		g.MakeNoise(BaseHitNoise, g.Player.Pos) // no real clang
+		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorGreen)
+		if RandInt(2) == 0 {
+			g.Corrosion(ev)
+		}
+	} else {
+		g.Stats.Dodges++
+		g.Printf("You dodge %s's acid projectile.", m.SeenIndefinite(g, false))
+		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorGreen)
+	}
+}
+
+func (m *monster) NixeAttraction(g *game, ev event) {
+	g.MakeNoise(9, m.Pos)
+	g.PrintfStyled("%s lures you to her.", logMonsterHit, m.SeenName(g, true))
+	ray := g.Ray(m.Pos)
+	g.ui.MonsterProjectileAnimation(ray, 'θ', ColorCyan) // TODO: improve
+	if len(ray) > 1 {
+		// should always be the case
+		g.ui.TeleportAnimation(g.Player.Pos, ray[1], true)
+		g.PlacePlayerAt(ray[1])
+	}
+}
+
+func (m *monster) AbsorbMana(g *game, ev event) {
+	g.Player.MP -= 1
+	g.Printf("%s absorbs your mana.", m.SeenName(g, true))
+}
+
+func (m *monster) MindAttack(g *game, ev event) {
+	dmg := 3 + RandInt(m.Attack) + RandInt(m.Attack) + RandInt(m.Attack)
+	dmg /= 3
+	m.InflictDamage(g, dmg, m.Attack)
+	g.Printf("%s hurts your mind (%d dmg).", m.SeenName(g, true), dmg)
+	if RandInt(2) == 0 {
+		if RandInt(2) == 0 {
+			g.PlayerAddEffect(EffSlow, EffSlow.Duration())
+		} else {
+			g.Confusion(ev)
+		}
+	}
+}
+
+func (m *monster) Explode(g *game, ev event) {
+	neighbors := m.Pos.ValidNeighbors()
+	g.MakeNoise(WallNoise, m.Pos)
+	g.Printf("%s %s explodes with a loud boom.", g.ExplosionSound(), m.Kind.Definite(true))
+	g.ui.ExplosionAnimation(FireExplosion, m.Pos)
+	for _, pos := range append(neighbors, m.Pos) {
+		c := g.Dungeon.Cell(pos)
+		if c.T == FreeCell {
+			g.Burn(pos, ev)
+		}
+		mons := g.MonsterAt(pos)
+		if mons.Exists() {
+			mons.HP /= 2
+			if mons.HP == 0 {
+				mons.HP = 1
+			}
+			g.MakeNoise(ExplosionHitNoise, mons.Pos)
+			g.HandleStone(mons)
+			mons.MakeHuntIfHurt(g)
+		} else if g.Player.Pos == pos {
+			dmg := g.Player.HP / 2
+			m.InflictDamage(g, dmg, 15)
+		} else if c.T == WallCell && RandInt(2) == 0 {
+			g.Dungeon.SetCell(pos, FreeCell)
+			g.Stats.Digs++
+			if !g.Player.LOS[pos] {
+				g.WrongWall[pos] = true
+			} else {
+				g.ui.WallExplosionAnimation(pos)
+			}
+			g.MakeNoise(WallNoise, pos)
+			g.Fog(pos, 1, ev)
+		}
+	}
+	g.ExplosionKnockback(m.Pos, ev)
+}
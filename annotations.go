@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxAnnotationLength is the maximal number of characters of a map note.
+const MaxAnnotationLength = 50
+
+func (g *game) Annotate(pos position, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		if _, ok := g.Annotations[pos]; ok {
+			delete(g.Annotations, pos)
+			g.Print("You remove the note.")
+		}
+		return
+	}
+	g.Annotations[pos] = text
+	g.Print("You take a note.")
+}
+
+func (g *game) AnnotatedPositions() []position {
+	ps := []position{}
+	for pos := range g.Annotations {
+		ps = append(ps, pos)
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i].idx() < ps[j].idx() })
+	return ps
+}
+
+func (g *game) DumpAnnotations() string {
+	if len(g.Annotations) == 0 {
+		return ""
+	}
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "Map notes:\n")
+	for _, pos := range g.AnnotatedPositions() {
+		fmt.Fprintf(buf, "- (%d,%d) %s\n", pos.X, pos.Y, g.Annotations[pos])
+	}
+	fmt.Fprintf(buf, "\n")
+	return buf.String()
+}
+
+func (ui *gameui) Annotate(pos position) error {
+	g := ui.g
+	if !g.Dungeon.Cell(pos).Explored {
+		return errors.New("You do not know this place.")
+	}
+	text, err := ui.ReadText("Note", g.Annotations[pos])
+	if err != nil {
+		return err
+	}
+	g.Annotate(pos, text)
+	return nil
+}
+
+// ReadText reads a line of text on the first line of the screen. Enter
+// validates the text and escape cancels.
+func (ui *gameui) ReadText(prompt, text string) (string, error) {
+	ui.textInput = true
+	defer func() {
+		ui.textInput = false
+	}()
+	for {
+		ui.ClearLine(0)
+		ui.DrawColoredText(prompt, 0, 0, ColorCyan)
+		col := utf8.RuneCountInString(prompt)
+		ui.DrawText(fmt.Sprintf(": %s_", text), col, 0)
+		ui.Flush()
+		in := ui.PollEvent()
+		switch in.key {
+		case "Enter", "\r", "\n":
+			return text, nil
+		case "\x1b", "Escape":
+			return "", errors.New(DoNothing)
+		case "BackSpace", "\b", "\x7f":
+			if n := len(text); n > 0 {
+				_, size := utf8.DecodeLastRuneInString(text)
+				text = text[:n-size]
+			}
+			continue
+		}
+		if in.mouse || utf8.RuneCountInString(in.key) != 1 || utf8.RuneCountInString(text) >= MaxAnnotationLength {
+			continue
+		}
+		r := ui.ReadKey(in.key)
+		if unicode.IsPrint(r) {
+			text += string(r)
+		}
+	}
+}
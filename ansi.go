@@ -24,6 +24,7 @@ type gameui struct {
 	cursor  position
 	stty    string
 	// below unused for this backend
+	textInput bool
 	menuHover menu
 	itemHover int
 }
@@ -4,14 +4,23 @@ import "errors"
 
 var DijkstraMapCache [DungeonNCells]int
 
+// ExploreHazardCache records the cells avoided by autoexplore when the
+// autoexplore map was last built, and ExploreSleepers the sleeping
+// monsters in view at that time.
+var ExploreHazardCache [DungeonNCells]bool
+var ExploreSleepers []int
+
 func (g *game) Autoexplore(ev event) error {
-	if mons := g.MonsterInLOS(); mons.Exists() {
+	if mons := g.ExploreBlockingMonster(); mons.Exists() {
 		return errors.New("You cannot auto-explore while there are monsters in view.")
 	}
 	if g.ExclusionsMap[g.Player.Pos] {
 		return errors.New("You cannot auto-explore while in an excluded area.")
 	}
 	if g.AllExplored() {
+		if g.ui.OfferStairsTravel() {
+			return g.GoToStairs(ev)
+		}
 		return errors.New("Nothing left to explore.")
 	}
 	sources := g.AutoexploreSources()
@@ -28,6 +37,19 @@ func (g *game) Autoexplore(ev event) error {
 	return g.MovePlayer(*n, ev)
 }
 
+// FinishExploring offers to travel to the nearest stairs once the level has
+// been explored. It returns true if the player moved.
+func (g *game) FinishExploring(ev event) bool {
+	if !g.ui.OfferStairsTravel() {
+		return false
+	}
+	if err := g.GoToStairs(ev); err != nil {
+		g.Print(err.Error())
+		return false
+	}
+	return true
+}
+
 func (g *game) AllExplored() bool {
 	np := &normalPath{game: g}
 	for i, c := range g.Dungeon.Cells {
@@ -38,9 +60,9 @@ func (g *game) AllExplored() bool {
 			}
 		}
 		_, okc := g.Collectables[pos]
-		if !c.Explored || g.Simellas[pos] > 0 || okc {
+		if !c.Explored || g.Simellas[pos] > 0 || okc || g.Keys[pos] || g.UnvisitedEquipable(pos) {
 			return false
-		} else if _, ok := g.Rods[pos]; ok {
+		} else if r, ok := g.Rods[pos]; ok && !g.Player.HasRod(r) {
 			return false
 		}
 	}
@@ -61,9 +83,9 @@ func (g *game) AutoexploreSources() []int {
 			continue
 		}
 		_, okc := g.Collectables[pos]
-		if !c.Explored || g.Simellas[pos] > 0 || okc {
+		if !c.Explored || g.Simellas[pos] > 0 || okc || g.Keys[pos] || g.UnvisitedEquipable(pos) {
 			sources = append(sources, i)
-		} else if _, ok := g.Rods[pos]; ok {
+		} else if r, ok := g.Rods[pos]; ok && !g.Player.HasRod(r) {
 			sources = append(sources, i)
 		}
 
@@ -72,6 +94,7 @@ func (g *game) AutoexploreSources() []int {
 }
 
 func (g *game) BuildAutoexploreMap(sources []int) {
+	g.ComputeExploreHazards()
 	ap := &autoexplorePath{game: g}
 	g.AutoExploreDijkstra(ap, sources)
 	g.DijkstraMapRebuild = false
@@ -101,3 +124,113 @@ func (g *game) NextAuto() (next *position, finished bool) {
 	next = &n
 	return next, finished
 }
+
+func (g *game) UnvisitedEquipable(pos position) bool {
+	if GameConfig.ExploreSkipItems {
+		return false
+	}
+	_, ok := g.Equipables[pos]
+	return ok && !g.VisitedItems[pos]
+}
+
+// SleeperExploreRadius is the minimal distance from a sleeping monster in
+// view for autoexplore to go on.
+const SleeperExploreRadius = 7
+
+// IgnoredSleeper reports whether autoexplore may go on with the given
+// monster in view.
+func (g *game) IgnoredSleeper(mons *monster) bool {
+	return !GameConfig.ExploreWaitSleepers && mons.State == Resting &&
+		mons.Pos.Distance(g.Player.Pos) >= SleeperExploreRadius
+}
+
+func (g *game) ExploreBlockingMonster() *monster {
+	for _, mons := range g.Monsters {
+		if mons.Visible(g) && !g.IgnoredSleeper(mons) {
+			return mons
+		}
+	}
+	return nil
+}
+
+func (stn stone) Dangerous() bool {
+	switch stn {
+	case TeleStone, TreeStone, ObstructionStone:
+		return true
+	default:
+		return false
+	}
+}
+
+// ComputeExploreHazards computes the cells that autoexplore should avoid
+// because of known harmful clouds, dangerous stones or sleeping monsters
+// nearby.
+func (g *game) ComputeExploreHazards() {
+	for i := range ExploreHazardCache {
+		ExploreHazardCache[i] = false
+	}
+	ExploreSleepers = ExploreSleepers[:0]
+	if !GameConfig.ExploreThroughHazards {
+		for pos, cld := range g.Clouds {
+			if cld.Gas() && g.Player.LOS[pos] {
+				ExploreHazardCache[pos.idx()] = true
+			}
+		}
+		for pos, stn := range g.MagicalStones {
+			if stn.Dangerous() && g.Dungeon.Cell(pos).Explored {
+				ExploreHazardCache[pos.idx()] = true
+			}
+		}
+	}
+	if GameConfig.ExploreWaitSleepers {
+		return
+	}
+	for _, mons := range g.Monsters {
+		if !mons.Visible(g) || mons.State != Resting {
+			continue
+		}
+		ExploreSleepers = append(ExploreSleepers, mons.Index)
+		for y := mons.Pos.Y - SleeperExploreRadius + 1; y < mons.Pos.Y+SleeperExploreRadius; y++ {
+			for x := mons.Pos.X - SleeperExploreRadius + 1; x < mons.Pos.X+SleeperExploreRadius; x++ {
+				pos := position{X: x, Y: y}
+				if pos.valid() {
+					ExploreHazardCache[pos.idx()] = true
+				}
+			}
+		}
+	}
+}
+
+// ExploreHazard reports whether autoexplore avoids pos.
+func (g *game) ExploreHazard(pos position) bool {
+	return pos.valid() && ExploreHazardCache[pos.idx()]
+}
+
+// CheckExploreSleepers marks the autoexplore map for rebuild when a sleeping
+// monster avoided by autoexplore wakes up or leaves view, or when a new one
+// comes into view.
+func (g *game) CheckExploreSleepers() {
+	if !g.Autoexploring || GameConfig.ExploreWaitSleepers {
+		return
+	}
+	for _, i := range ExploreSleepers {
+		if i >= len(g.Monsters) {
+			g.DijkstraMapRebuild = true
+			return
+		}
+		mons := g.Monsters[i]
+		if mons.State != Resting || !mons.Visible(g) {
+			g.DijkstraMapRebuild = true
+			return
+		}
+	}
+	sleepers := 0
+	for _, mons := range g.Monsters {
+		if mons.Visible(g) && mons.State == Resting {
+			sleepers++
+		}
+	}
+	if sleepers != len(ExploreSleepers) {
+		g.DijkstraMapRebuild = true
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultAutoFightHP is the default HP percent under which auto-fight
+// refuses to go on.
+const DefaultAutoFightHP = 50
+
+func AutoFightHP() int {
+	if GameConfig.AutoFightHP == 0 {
+		return DefaultAutoFightHP
+	}
+	return GameConfig.AutoFightHP
+}
+
+// NextAutoFightHP returns the next auto-fight HP threshold in the settings
+// cycle.
+func NextAutoFightHP() int {
+	switch AutoFightHP() {
+	case 30:
+		return 50
+	case 50:
+		return 70
+	default:
+		return 30
+	}
+}
+
+func (g *game) AutoFightHPLow() bool {
+	return g.Player.HP*100 < AutoFightHP()*g.Player.HPMax()
+}
+
+func (g *game) CanHitFrom(from, to position) bool {
+	if from.Distance(to) != 1 {
+		return false
+	}
+	if g.Player.HasEffect(EffConfusion) {
+		switch to.Dir(from) {
+		case E, N, W, S:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// AutoFightTarget returns the most sensible visible monster to fight,
+// along with the path to it: monsters that can be hit right away come
+// first, the weakest first, then the nearest reachable ones.
+func (g *game) AutoFightTarget() (*monster, []position) {
+	var target *monster
+	var tpath []position
+	for _, mons := range g.Monsters {
+		if !mons.Visible(g) {
+			continue
+		}
+		if g.CanHitFrom(g.Player.Pos, mons.Pos) {
+			if target == nil || tpath != nil || mons.HP < target.HP {
+				target = mons
+				tpath = nil
+			}
+			continue
+		}
+		if target != nil && tpath == nil {
+			continue
+		}
+		path := g.PlayerPath(g.Player.Pos, mons.Pos)
+		if len(path) < 2 {
+			continue
+		}
+		if target == nil || len(path) < len(tpath) {
+			target = mons
+			tpath = path
+		}
+	}
+	return target, tpath
+}
+
+func (g *game) AutoFight(ev event) error {
+	if g.AutoFightHPLow() {
+		return fmt.Errorf("You cannot auto-fight with less than %d%% HP.", AutoFightHP())
+	}
+	g.AutoFighting = true
+	err := g.AutoFightStep(ev)
+	if err != nil {
+		g.AutoFighting = false
+	}
+	return err
+}
+
+// AutoFightStep attacks the auto-fight target if it is adjacent, or moves
+// one step toward it otherwise.
+func (g *game) AutoFightStep(ev event) error {
+	mons, path := g.AutoFightTarget()
+	if mons == nil {
+		return errors.New("There are no reachable monsters in view.")
+	}
+	if path == nil {
+		return g.MovePlayer(mons.Pos, ev)
+	}
+	next := path[len(path)-2]
+	if !g.MonsterAt(next).Exists() && !g.ui.ConfirmAutoFightStep(next) {
+		return errors.New(DoNothing)
+	}
+	return g.MovePlayer(next, ev)
+}
+
+// AutoFightHalt stops auto-fight, with a message if it was active.
+func (g *game) AutoFightHalt() {
+	if g.AutoFighting {
+		g.AutoFighting = false
+		g.Print("You stop fighting.")
+	}
+}
+
+func (ui *gameui) ConfirmAutoFightStep(pos position) bool {
+	g := ui.g
+	var what string
+	if cld, ok := g.Clouds[pos]; ok {
+		what = fmt.Sprintf("into %s", cld)
+	} else if stn, ok := g.MagicalStones[pos]; ok && stn != InertStone {
+		what = fmt.Sprintf("onto %s", Indefinite(stn.String(), false))
+	} else {
+		return true
+	}
+	g.Printf("Do you really want to step %s? [y/N]", what)
+	ui.DrawDungeonView(NormalMode)
+	return ui.PromptConfirmation()
+}
@@ -0,0 +1,153 @@
+package main
+
+import "fmt"
+
+type background int
+
+const (
+	BgWanderer background = iota
+	BgBrawler
+	BgEvoker
+	BgSkirmisher
+)
+
+var Backgrounds = []background{BgWanderer, BgBrawler, BgEvoker, BgSkirmisher}
+
+func (bg background) String() (text string) {
+	switch bg {
+	case BgWanderer:
+		text = "wanderer"
+	case BgBrawler:
+		text = "brawler"
+	case BgEvoker:
+		text = "evoker"
+	case BgSkirmisher:
+		text = "skirmisher"
+	}
+	return text
+}
+
+func (bg background) Desc() (text string) {
+	switch bg {
+	case BgWanderer:
+		text = "Wanderers come with a random rod, a potion of heal wounds, and a random pick of potions and projectiles."
+	case BgBrawler:
+		text = "Brawlers are sturdy and strong, but have little magic. They start with an axe, a rod of digging, and potions to fuel a fight."
+	case BgEvoker:
+		text = "Evokers have big magic reserves but are frail. They start with two attack rods and a potion of magic."
+	case BgSkirmisher:
+		text = "Skirmishers are agile fighters that keep their foes at bay. They start with a rod of blinking, some darts and a slowing magara."
+	}
+	return text
+}
+
+func (bg background) HPBonus() int {
+	switch bg {
+	case BgBrawler:
+		return 6
+	case BgEvoker:
+		return -6
+	case BgSkirmisher:
+		return -2
+	}
+	return 0
+}
+
+func (bg background) MPBonus() int {
+	switch bg {
+	case BgBrawler:
+		return -1
+	case BgEvoker:
+		return 1
+	}
+	return 0
+}
+
+func (g *game) InitBackgroundKit() {
+	p := g.Player
+	var r rod
+	switch p.Background {
+	case BgBrawler:
+		p.Weapon = Axe
+		p.Aptitudes[AptStrong] = true
+		p.Consumables[HealWoundsPotion] = 2
+		p.Consumables[BerserkPotion] = 1
+		p.Consumables[ConfusingDart] = 2
+		r = RodDigging
+	case BgEvoker:
+		p.Aptitudes[AptMagic] = true
+		p.Consumables[MagicPotion] = 1
+		p.Consumables[ConfuseMagara] = 1
+		r = RodFireBolt
+		second := []rod{RodLightning, RodSleeping, RodFog}[RandInt(3)]
+		p.Rods[second] = rodProps{Charge: second.MaxCharge() - 1}
+	case BgSkirmisher:
+		p.Aptitudes[AptAgile] = true
+		p.Consumables[ConfusingDart] = 4
+		p.Consumables[SlowingMagara] = 1
+		p.Consumables[SwiftnessPotion] = 1
+		r = RodBlink
+	default:
+		g.RandomStartingConsumables()
+		r = g.RandomRod()
+	}
+	p.Rods[r] = rodProps{Charge: r.MaxCharge() - 1}
+}
+
+func (g *game) RandomStartingConsumables() {
+	switch RandInt(7) {
+	case 0:
+		g.Player.Consumables[ExplosiveMagara] = 1
+	case 1:
+		g.Player.Consumables[NightMagara] = 1
+	case 2:
+		g.Player.Consumables[TeleportMagara] = 1
+	case 3:
+		g.Player.Consumables[SlowingMagara] = 1
+	case 4:
+		g.Player.Consumables[ConfuseMagara] = 1
+	default:
+		g.Player.Consumables[ConfusingDart] = 2
+	}
+	switch RandInt(12) {
+	case 0, 1:
+		g.Player.Consumables[TeleportationPotion] = 1
+	case 2, 3:
+		g.Player.Consumables[BerserkPotion] = 1
+	case 4:
+		g.Player.Consumables[SwiftnessPotion] = 1
+	case 5:
+		g.Player.Consumables[LignificationPotion] = 1
+	case 6:
+		g.Player.Consumables[WallPotion] = 1
+	case 7:
+		g.Player.Consumables[CBlinkPotion] = 1
+	case 8:
+		g.Player.Consumables[DigPotion] = 1
+	case 9:
+		g.Player.Consumables[SwapPotion] = 1
+	case 10:
+		g.Player.Consumables[ShadowsPotion] = 1
+	case 11:
+		g.Player.Consumables[AccuracyPotion] = 1
+	}
+}
+
+func (g *game) BackgroundStoryText() string {
+	items := []string{}
+	for _, r := range g.SortedRods() {
+		items = append(items, r.String())
+	}
+	for c, n := range g.Player.Consumables {
+		if n == 1 {
+			items = append(items, c.String())
+		} else {
+			items = append(items, fmt.Sprintf("%d %s", n, c.Plural()))
+		}
+	}
+	text := fmt.Sprintf("Started as %s with %s", Indefinite(g.Player.Background.String(), false), g.Player.Weapon)
+	for _, it := range items {
+		text += ", " + it
+	}
+	return text
+}
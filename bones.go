@@ -0,0 +1,122 @@
+package main
+
+import "fmt"
+
+// DisableBones disables writing and loading bones files.
+var DisableBones bool
+
+// bones records what remains of a dead character, so that its ghost can be
+// met in a later game.
+type bones struct {
+	Version    string
+	Depth      int
+	Background background
+	HPMax      int
+	Attack     int
+	Armor      int
+	Evasion    int
+	Armour     armour
+	Weapon     weapon
+	Shield     shield
+	Rods       []rod
+}
+
+type ghostStatus int
+
+const (
+	GhostNone ghostStatus = iota
+	GhostSpawned
+	GhostSeen
+	GhostKilled
+)
+
+func (g *game) NewBones() *bones {
+	b := &bones{
+		Version:    Version,
+		Depth:      g.Depth,
+		Background: g.Player.Background,
+		HPMax:      g.Player.HPMax(),
+		Attack:     g.Player.Attack(),
+		Armor:      g.Player.Armor(),
+		Evasion:    g.Player.Evasion(),
+		Armour:     g.Player.Armour,
+		Weapon:     g.Player.Weapon,
+		Shield:     g.Player.Shield,
+	}
+	b.Rods = append(b.Rods, g.SortedRods()...)
+	return b
+}
+
+// BonesWorthy reports whether the current dead character should leave
+// bones behind.
+func (g *game) BonesWorthy() bool {
+	return !DisableBones && !g.Wizard && g.Depth >= 2 && g.Depth <= MaxDepth
+}
+
+func (g *game) SpawnGhost() {
+	b := g.Bones
+	if b == nil || b.Depth != g.Depth || g.GhostStatus != GhostNone {
+		return
+	}
+	mons := &monster{Kind: MonsGhost}
+	mons.Init()
+	mons.HPmax = b.HPMax
+	mons.HP = mons.HPmax
+	mons.Attack = b.Attack
+	mons.Armor = b.Armor
+	mons.Evasion = b.Evasion
+	if b.Armour != Robe {
+		mons.Gear = append(mons.Gear, b.Armour)
+	}
+	if b.Weapon != Dagger {
+		mons.Gear = append(mons.Gear, b.Weapon)
+	}
+	if b.Shield != NoShield {
+		mons.Gear = append(mons.Gear, b.Shield)
+	}
+	mons.Rods = append(mons.Rods, b.Rods...)
+	// do not generate the ghost's items again
+	for _, eq := range mons.Gear {
+		g.GeneratedEquipables[eq] = true
+	}
+	for _, r := range mons.Rods {
+		g.GeneratedRods[r] = true
+	}
+	g.AddMonster(mons, g.NewBand(GhostBandData), g.FreeCellForMonster())
+	g.GhostStatus = GhostSpawned
+}
+
+func (g *game) GhostSeen(mons *monster) {
+	if mons.Kind != MonsGhost || g.GhostStatus != GhostSpawned {
+		return
+	}
+	g.GhostStatus = GhostSeen
+	g.PrintStyled("You feel the cold stare of a fallen adventurer.", logSpecial)
+	g.StoryPrintf("Met the ghost of %s who died here.", Indefinite(g.Bones.Background.String(), false))
+}
+
+func (g *game) GhostKilled(mons *monster) {
+	if mons.Kind != MonsGhost {
+		return
+	}
+	g.GhostStatus = GhostKilled
+	g.PrintStyled("The ghost vanishes, finally at peace.", logSpecial)
+	g.StoryPrint("Laid a ghost to rest.")
+}
+
+func (g *game) DumpGhost() string {
+	b := g.Bones
+	if b == nil || g.GhostStatus == GhostNone {
+		return ""
+	}
+	var s string
+	switch g.GhostStatus {
+	case GhostSpawned:
+		s = "The ghost of %s haunted depth %d, but you did not meet it.\n"
+	case GhostSeen:
+		s = "You met the ghost of %s on depth %d.\n"
+	case GhostKilled:
+		s = "You laid to rest the ghost of %s on depth %d.\n"
+	}
+	return fmt.Sprintf(s, Indefinite(b.Background.String(), false), b.Depth)
+}
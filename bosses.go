@@ -0,0 +1,287 @@
+package main
+
+type boss int
+
+const (
+	BossGoblinWarlord boss = iota
+	BossEarthShaper
+)
+
+const NumBosses = int(BossEarthShaper) + 1
+
+type phaseAction int
+
+const (
+	PhaseSummon  phaseAction = iota // summon the boss band
+	PhaseTerrain                    // collapse walls and raise temporal walls
+	PhaseBlink                      // teleport away and recover a little
+)
+
+type bossPhase struct {
+	hp     int // HP percent threshold
+	action phaseAction
+}
+
+type bossData struct {
+	kind   monsterKind
+	depth  int
+	band   map[monsterKind]monsInterval
+	phases []bossPhase
+	seen   string
+	death  string
+}
+
+var BossesData = [NumBosses]bossData{
+	BossGoblinWarlord: {
+		kind:  MonsGoblinWarlord,
+		depth: 3,
+		band:  map[monsterKind]monsInterval{MonsGoblin: {2, 2}, MonsGoblinWarrior: {1, 1}},
+		phases: []bossPhase{
+			{hp: 66, action: PhaseSummon},
+			{hp: 33, action: PhaseBlink},
+		},
+		seen:  "Faced Gorbulg the goblin warlord in his arena.",
+		death: "Gorbulg falls to the ground with a last war cry. The goblins of the Underground have lost their warlord!",
+	},
+	BossEarthShaper: {
+		kind:  MonsEarthShaper,
+		depth: WinDepth - 1,
+		band:  map[monsterKind]monsInterval{MonsSkeletonWarrior: {2, 2}, MonsLich: {0, 1}},
+		phases: []bossPhase{
+			{hp: 75, action: PhaseTerrain},
+			{hp: 50, action: PhaseSummon},
+			{hp: 25, action: PhaseBlink},
+		},
+		seen:  "Faced Ashkareth the earth shaper in her arena.",
+		death: "Ashkareth crumbles into dust, and the stones around you stop trembling.",
+	},
+}
+
+func (mk monsterKind) Boss() (boss, bool) {
+	for i, bd := range BossesData {
+		if bd.kind == mk {
+			return boss(i), true
+		}
+	}
+	return 0, false
+}
+
+func (b boss) Kind() monsterKind {
+	return BossesData[b].kind
+}
+
+func (g *game) LevelBoss() (boss, bool) {
+	for i, bd := range BossesData {
+		if bd.depth == g.Depth && !g.GeneratedBosses[boss(i)] {
+			return boss(i), true
+		}
+	}
+	return 0, false
+}
+
+// GenBossArena carves a roomy area far from the player and returns its
+// center.
+func (g *game) GenBossArena() position {
+	center := g.BossArenaCenter()
+	if center.valid() {
+		g.CarveArena(center)
+	}
+	return center
+}
+
+func (g *game) BossArenaCenter() position {
+	for i := 0; i < 1000; i++ {
+		pos := g.FreeCellForMonster()
+		if pos.X < 6 || pos.X > DungeonWidth-7 || pos.Y < 4 || pos.Y > DungeonHeight-5 {
+			continue
+		}
+		if pos.Distance(g.Player.Pos) < 20 && i < 900 {
+			continue
+		}
+		if pos.Distance(g.Player.Pos) < 8 {
+			continue
+		}
+		return pos
+	}
+	return InvalidPos
+}
+
+func InArena(center, pos position) bool {
+	dx := center.DistanceX(pos)
+	dy := center.DistanceY(pos)
+	return dx <= 5 && dy <= 3 && !(dx >= 4 && dy == 3)
+}
+
+func (g *game) CarveArena(center position) {
+	for y := center.Y - 3; y <= center.Y+3; y++ {
+		for x := center.X - 5; x <= center.X+5; x++ {
+			pos := position{x, y}
+			if !InArena(center, pos) {
+				continue
+			}
+			g.Dungeon.SetCell(pos, FreeCell)
+			delete(g.Fungus, pos)
+			delete(g.Doors, pos)
+		}
+	}
+}
+
+// SpawnBoss places a boss at pos, or at a free cell near it.
+func (g *game) SpawnBoss(b boss, pos position) *monster {
+	g.GeneratedBosses[b] = true
+	mons := &monster{Kind: b.Kind()}
+	mons.Init()
+	if g.MonsterAt(pos).Exists() || pos == g.Player.Pos {
+		pos = g.FreeCellForBandMonster(pos)
+	}
+	g.AddMonster(mons, g.NewBand(BossBandData), pos)
+	return mons
+}
+
+// BossBandData and GhostBandData describe the bands of monsters placed
+// outside random generation. Their zero depth range keeps them out of
+// GenMonsters and GenReinforcements.
+var (
+	BossBandData  = monsterBandData{Band: true, Unique: true}
+	GhostBandData = monsterBandData{Monster: MonsGhost, Unique: true}
+)
+
+// NewBand registers a new band with the given data for monsters created
+// after level generation.
+func (g *game) NewBand(data monsterBandData) int {
+	g.BandData = append(g.BandData, data)
+	g.Bands = append(g.Bands, monsterBand(len(g.BandData)-1))
+	return len(g.Bands) - 1
+}
+
+// AddMonster adds a new monster to the level and schedules its first turn.
+// Monster events pushed during level generation are replaced by InitLevel.
+func (g *game) AddMonster(mons *monster, band int, pos position) {
+	mons.Index = len(g.Monsters)
+	mons.Band = band
+	mons.PlaceAt(g, pos)
+	g.Monsters = append(g.Monsters, mons)
+	if g.Events != nil {
+		g.PushEvent(&monsterEvent{ERank: g.Turn + RandInt(10), EAction: MonsterTurn, NMons: mons.Index})
+	}
+}
+
+// BossPhase triggers the next phase of a boss when its HP goes below the
+// phase threshold. It returns true if the boss used its turn.
+func (m *monster) BossPhase(g *game, ev event) bool {
+	b, ok := m.Kind.Boss()
+	if !ok {
+		return false
+	}
+	phases := BossesData[b].phases
+	if m.Phase >= len(phases) || m.HP*100 > phases[m.Phase].hp*m.HPmax {
+		return false
+	}
+	ph := phases[m.Phase]
+	m.Phase++
+	switch ph.action {
+	case PhaseSummon:
+		m.SummonBand(g, b, ev)
+	case PhaseTerrain:
+		m.ShakeTerrain(g, ev)
+	case PhaseBlink:
+		m.TeleportAway(g)
+		m.HP += m.HPmax / 6
+		if m.HP > m.HPmax {
+			m.HP = m.HPmax
+		}
+	}
+	g.StopAuto()
+	ev.Renew(g, m.Kind.AttackDelay())
+	return true
+}
+
+func (m *monster) SummonBand(g *game, b boss, ev event) {
+	g.MakeNoise(BarkNoise, m.Pos)
+	if g.Player.LOS[m.Pos] {
+		g.PrintfStyled("%s calls for help!", logMonsterHit, m.Kind.Definite(true))
+	} else {
+		g.Print("You hear a loud call for help.")
+	}
+	for mk, interval := range BossesData[b].band {
+		for i := 0; i < interval.Min+RandInt(interval.Max-interval.Min+1); i++ {
+			pos := g.FreeCellForBandMonster(m.Pos)
+			mons := &monster{Kind: mk}
+			mons.Init()
+			g.AddMonster(mons, m.Band, pos)
+			mons.MakeHunt(g)
+		}
+	}
+	g.ComputeLOS()
+}
+
+func (m *monster) ShakeTerrain(g *game, ev event) {
+	g.MakeNoise(WallNoise, m.Pos)
+	g.PrintfStyled("%s strikes the ground. The walls tremble!", logMonsterHit, m.Kind.Definite(true))
+	for y := m.Pos.Y - 4; y <= m.Pos.Y+4; y++ {
+		for x := m.Pos.X - 7; x <= m.Pos.X+7; x++ {
+			pos := position{x, y}
+			if !pos.valid() || pos == m.Pos {
+				continue
+			}
+			c := g.Dungeon.Cell(pos)
+			switch {
+			case c.T == WallCell && !g.TemporalWalls[pos] && RandInt(3) == 0:
+				if x == 0 || y == 0 || x == DungeonWidth-1 || y == DungeonHeight-1 {
+					continue
+				}
+				g.Dungeon.SetCell(pos, FreeCell)
+				if !g.Player.LOS[pos] {
+					g.WrongWall[pos] = !g.WrongWall[pos]
+				}
+				g.Fog(pos, 1, ev)
+			case c.T == FreeCell && RandInt(10) == 0:
+				if pos == g.Player.Pos || g.MonsterAt(pos).Exists() || g.ObjectAt(pos) || g.IsDoor(pos) {
+					continue
+				}
+				if _, ok := g.Stairs[pos]; ok {
+					continue
+				}
+				if _, ok := g.MagicalStones[pos]; ok {
+					continue
+				}
+				if !g.Player.LOS[pos] {
+					g.WrongWall[pos] = !g.WrongWall[pos]
+				}
+				g.CreateTemporalWallAt(pos, ev)
+			}
+		}
+	}
+	g.DijkstraMapRebuild = true
+	g.ComputeLOS()
+}
+
+func (g *game) BossKilled(m *monster) bool {
+	b, ok := m.Kind.Boss()
+	if !ok {
+		return false
+	}
+	g.PrintStyled(BossesData[b].death, logSpecial)
+	g.StoryPrintf("Defeated %s.", m.Kind)
+	return true
+}
+
+// WizardSpawnBosses brings all the bosses not met yet near the player.
+func (g *game) WizardSpawnBosses() {
+	spawned := false
+	for i := range BossesData {
+		b := boss(i)
+		if g.GeneratedBosses[b] {
+			continue
+		}
+		mons := g.SpawnBoss(b, g.FreeCellForBandMonster(g.Player.Pos))
+		mons.MakeHunt(g)
+		spawned = true
+	}
+	if spawned {
+		g.Print("Bosses have been summoned nearby.")
+	} else {
+		g.Print("All bosses have already been generated.")
+	}
+	g.ComputeLOS()
+}
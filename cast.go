@@ -0,0 +1,137 @@
+// +build !js
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// ExportCast converts a replay file into an asciicast v2 file. Pauses
+// between frames longer than maxIdle are shortened to maxIdle, unless
+// maxIdle is zero.
+func ExportCast(replayFile, castFile string, maxIdle time.Duration) error {
+	g := &game{}
+	ui := &gameui{g: g}
+	g.ui = ui
+	err := g.LoadReplay(replayFile)
+	if err != nil {
+		return fmt.Errorf("loading replay: %v", err)
+	}
+	if len(g.DrawLog) == 0 {
+		return fmt.Errorf("empty replay")
+	}
+	f, err := os.Create(castFile)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	err = ui.WriteCast(w, g.DrawLog, maxIdle)
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing cast: %v", err)
+	}
+	return nil
+}
+
+type castHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Title     string `json:"title"`
+}
+
+func frameSize(frames []drawFrame) (w, h int) {
+	w, h = 80, 24
+	for _, df := range frames {
+		for _, dr := range df.Draws {
+			if dr.X >= w {
+				w = dr.X + 1
+			}
+			if dr.Y >= h {
+				h = dr.Y + 1
+			}
+		}
+	}
+	return w, h
+}
+
+// CastFrame returns the ANSI escape sequences drawing a replay frame, using
+// 256 colors.
+func (ui *gameui) CastFrame(df drawFrame) string {
+	buf := &bytes.Buffer{}
+	var prevfg, prevbg uicolor
+	var prevx, prevy int
+	for i, dr := range df.Draws {
+		fg := ui.Map16ColorTo256(dr.Cell.Fg)
+		bg := ui.Map16ColorTo256(dr.Cell.Bg)
+		if i == 0 || dr.X != prevx+1 || dr.Y != prevy {
+			fmt.Fprintf(buf, "\x1b[%d;%dH", dr.Y+1, dr.X+1)
+		}
+		if i == 0 || fg != prevfg {
+			fmt.Fprintf(buf, "\x1b[38;5;%dm", fg)
+		}
+		if i == 0 || bg != prevbg {
+			fmt.Fprintf(buf, "\x1b[48;5;%dm", bg)
+		}
+		buf.WriteRune(dr.Cell.R)
+		prevfg, prevbg = fg, bg
+		prevx, prevy = dr.X, dr.Y
+	}
+	if len(df.Draws) > 0 {
+		fmt.Fprintf(buf, "\x1b[0m")
+	}
+	return buf.String()
+}
+
+func (ui *gameui) WriteCast(w io.Writer, frames []drawFrame, maxIdle time.Duration) error {
+	width, height := frameSize(frames)
+	start := frames[0].Time
+	header := castHeader{Version: 2, Width: width, Height: height, Timestamp: start.Unix(),
+		Title: fmt.Sprintf("Boohu %s replay", Version)}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(header)
+	if err != nil {
+		return err
+	}
+	event := func(t time.Duration, data string) error {
+		return enc.Encode([]interface{}{t.Seconds(), "o", data})
+	}
+	err = event(0, "\x1b[2J\x1b[?25l")
+	if err != nil {
+		return err
+	}
+	var t time.Duration
+	for i, df := range frames {
+		if i > 0 {
+			d := df.Time.Sub(frames[i-1].Time)
+			if d < 0 {
+				d = 0
+			}
+			if maxIdle > 0 && d > maxIdle {
+				d = maxIdle
+			}
+			t += d
+		}
+		if len(df.Draws) == 0 {
+			continue
+		}
+		err = event(t, ui.CastFrame(df))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
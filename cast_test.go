@@ -0,0 +1,84 @@
+// +build !js
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCastFrame(t *testing.T) {
+	ui := &gameui{}
+	type tableTest struct {
+		df   drawFrame
+		want string
+	}
+	cell := UICell{R: '@', Fg: Color16Red, Bg: Color16Base03}
+	table := []tableTest{
+		{drawFrame{}, ""},
+		{drawFrame{Draws: []cellDraw{{Cell: cell, X: 0, Y: 0}}},
+			"\x1b[1;1H\x1b[38;5;160m\x1b[48;5;234m@\x1b[0m"},
+		{drawFrame{Draws: []cellDraw{{Cell: cell, X: 4, Y: 2}, {Cell: cell, X: 5, Y: 2}, {Cell: UICell{R: 'x', Fg: Color16Red, Bg: Color16Red}, X: 7, Y: 2}}},
+			"\x1b[3;5H\x1b[38;5;160m\x1b[48;5;234m@@\x1b[3;8H\x1b[48;5;160mx\x1b[0m"},
+	}
+	for _, test := range table {
+		if s := ui.CastFrame(test.df); s != test.want {
+			t.Errorf("Bad cast frame %q instead of %q", s, test.want)
+		}
+	}
+}
+
+func TestWriteCast(t *testing.T) {
+	ui := &gameui{}
+	start := time.Unix(1500000000, 0)
+	cell := UICell{R: '@', Fg: Color16Red, Bg: Color16Base03}
+	frames := []drawFrame{
+		{Time: start},
+		{Time: start.Add(500 * time.Millisecond), Draws: []cellDraw{{Cell: cell, X: 90, Y: 3}}},
+		{Time: start.Add(time.Hour), Draws: []cellDraw{{Cell: cell, X: 1, Y: 30}}},
+	}
+	buf := &bytes.Buffer{}
+	err := ui.WriteCast(buf, frames, 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := bufio.NewScanner(buf)
+	lines := []string{}
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if len(lines) != 4 {
+		t.Fatalf("Bad number of cast lines: %d", len(lines))
+	}
+	header := castHeader{}
+	err = json.Unmarshal([]byte(lines[0]), &header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Version != 2 || header.Width != 91 || header.Height != 31 || header.Timestamp != start.Unix() {
+		t.Errorf("Bad cast header: %+v", header)
+	}
+	type tableTest struct {
+		line int
+		t    float64
+		data string
+	}
+	table := []tableTest{
+		{1, 0, "\x1b[2J\x1b[?25l"},
+		{2, 0.5, ui.CastFrame(frames[1])},
+		{3, 2.5, ui.CastFrame(frames[2])},
+	}
+	for _, test := range table {
+		var ev []interface{}
+		err := json.Unmarshal([]byte(lines[test.line]), &ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ev) != 3 || ev[0] != test.t || ev[1] != "o" || ev[2] != test.data {
+			t.Errorf("Bad cast event on line %d: %q", test.line, lines[test.line])
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type championMod int
+
+const (
+	ChampSwift championMod = iota
+	ChampArmoured
+	ChampRegenerating
+	ChampVampiric
+	ChampExplosive
+)
+
+const NumChampionMods = int(ChampExplosive) + 1
+
+func (cm championMod) String() (text string) {
+	switch cm {
+	case ChampSwift:
+		text = "swift"
+	case ChampArmoured:
+		text = "armoured"
+	case ChampRegenerating:
+		text = "regenerating"
+	case ChampVampiric:
+		text = "vampiric"
+	case ChampExplosive:
+		text = "explosive"
+	}
+	return text
+}
+
+func (cm championMod) Desc() (text string) {
+	switch cm {
+	case ChampSwift:
+		text = "moves faster"
+	case ChampArmoured:
+		text = "has a thicker hide"
+	case ChampRegenerating:
+		text = "recovers health over time"
+	case ChampVampiric:
+		text = "heals when hitting you"
+	case ChampExplosive:
+		text = "explodes when killed"
+	}
+	return text
+}
+
+func (cm championMod) Apply(m *monster) {
+	switch cm {
+	case ChampArmoured:
+		m.Armor += 4
+		m.HPmax += m.HPmax / 5
+		m.HP = m.HPmax
+	case ChampRegenerating:
+		m.HPmax += m.HPmax / 10
+		m.HP = m.HPmax
+	}
+}
+
+func (m *monster) Champion() bool {
+	for _, b := range m.Mods {
+		if b {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *monster) HasMod(cm championMod) bool {
+	return m.Mods[cm]
+}
+
+func (m *monster) SortedMods() []championMod {
+	mods := []championMod{}
+	for i, b := range m.Mods {
+		if b {
+			mods = append(mods, championMod(i))
+		}
+	}
+	return mods
+}
+
+func (m *monster) ModsText() string {
+	mods := []string{}
+	for _, cm := range m.SortedMods() {
+		mods = append(mods, cm.String())
+	}
+	return strings.Join(mods, " ")
+}
+
+func (m *monster) Name() string {
+	if !m.Champion() {
+		return m.Kind.String()
+	}
+	return fmt.Sprintf("%s %s champion", m.ModsText(), m.Kind)
+}
+
+func (m *monster) Danger() int {
+	d := m.Kind.Dangerousness()
+	for _, b := range m.Mods {
+		if b {
+			d += ChampionDanger(m.Kind)
+		}
+	}
+	return d
+}
+
+func ChampionDanger(mk monsterKind) int {
+	return 1 + mk.Dangerousness()/2
+}
+
+func (m *monster) MovementDelay() int {
+	delay := m.Kind.MovementDelay()
+	if m.HasMod(ChampSwift) {
+		delay -= 3
+	}
+	if m.HasEffect(EffSwift) {
+		delay -= 3
+	}
+	if delay < 3 {
+		delay = 3
+	}
+	return delay
+}
+
+// MakeChampion may turn a new monster into a champion with one or two
+// modifiers, within the remaining danger budget. It returns the extra
+// danger.
+func (g *game) MakeChampion(m *monster, danger int) int {
+	if g.Depth < 2 || m.Kind == MonsMarevorHelith || m.Kind == MonsSatowalgaPlant {
+		return 0
+	}
+	if RandInt(100) >= 3+g.Depth {
+		return 0
+	}
+	n := 1
+	if g.Depth >= 6 && RandInt(3) == 0 {
+		n = 2
+	}
+	cost := ChampionDanger(m.Kind)
+	if danger-n*cost <= 0 {
+		return 0
+	}
+	for i := 0; i < n; i++ {
+		cm := championMod(RandInt(NumChampionMods))
+		if m.Mods[cm] || cm == ChampExplosive && m.Kind == MonsExplosiveNadre ||
+			cm == ChampVampiric && m.Kind == MonsVampire {
+			continue
+		}
+		m.Mods[cm] = true
+		cm.Apply(m)
+	}
+	if !m.Champion() {
+		return 0
+	}
+	return len(m.SortedMods()) * cost
+}
+
+func (g *game) ChampionRegen(m *monster) {
+	if m.HasMod(ChampRegenerating) && m.HP < m.HPmax && !m.HasEffect(EffRegeneration) {
+		m.AddEffect(g, EffRegeneration, EffRegeneration.Duration())
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+func (cld cloud) String() (text string) {
+	switch cld {
+	case CloudFog:
+		text = "a dense fog"
+	case CloudFire:
+		text = "burning flames"
+	case CloudNight:
+		text = "night clouds"
+	case CloudPoison:
+		text = "poisonous gas"
+	case CloudSteam:
+		text = "scalding steam"
+	case CloudConfusion:
+		text = "confusing gas"
+	}
+	return text
+}
+
+func (cld cloud) Opaque() bool {
+	switch cld {
+	case CloudPoison, CloudConfusion:
+		return false
+	}
+	return true
+}
+
+func (cld cloud) Gas() bool {
+	switch cld {
+	case CloudPoison, CloudSteam, CloudConfusion:
+		return true
+	}
+	return false
+}
+
+const MaxGasDensity = 9
+
+// Gas releases a spreading gas cloud of the given density at pos.
+func (g *game) Gas(pos position, cld cloud, density int, ev event) {
+	if !pos.valid() || g.Dungeon.Cell(pos).T == WallCell {
+		return
+	}
+	if ocld, ok := g.Clouds[pos]; ok {
+		if ocld != cld {
+			return
+		}
+		g.GasDensity[pos] += density
+		if g.GasDensity[pos] > MaxGasDensity {
+			g.GasDensity[pos] = MaxGasDensity
+		}
+		return
+	}
+	if density > MaxGasDensity {
+		density = MaxGasDensity
+	}
+	g.Clouds[pos] = cld
+	g.GasDensity[pos] = density
+	// one progression chain per cell: an event still pending from previous
+	// gas on this cell takes care of the new gas
+	if !g.GasPending[pos] {
+		g.GasPending[pos] = true
+		g.PushEvent(&cloudEvent{ERank: ev.Rank() + 10, EAction: GasProgression, Pos: pos})
+	}
+	if g.Player.LOS[pos] {
+		g.DijkstraMapRebuild = true
+	}
+	if cld.Opaque() {
+		g.ComputeLOS()
+	}
+}
+
+func (g *game) GasProgression(cev *cloudEvent) {
+	pos := cev.Pos
+	cld, ok := g.Clouds[pos]
+	if !ok || !cld.Gas() {
+		delete(g.GasPending, pos)
+		return
+	}
+	g.GasCreature(pos, cld, cev)
+	density := g.GasDensity[pos] - 1
+	if density >= 2 {
+		if npos, ok := g.GasSpreadPosition(pos, cld); ok {
+			share := density / 2
+			density -= share
+			g.Gas(npos, cld, share, cev)
+		}
+	}
+	if density <= 0 {
+		g.RemoveCloud(pos)
+		delete(g.GasPending, pos)
+		return
+	}
+	g.GasDensity[pos] = density
+	cev.Renew(g, 10)
+}
+
+// GasSpreadPosition returns a free neighbour where gas at pos can spread,
+// preferring more open places, so that gas drifts out of closed areas.
+func (g *game) GasSpreadPosition(pos position, cld cloud) (position, bool) {
+	area := make([]position, 9)
+	best := InvalidPos
+	bestWalls := 10
+	for _, npos := range g.Dungeon.FreeNeighbors(pos) {
+		if ocld, ok := g.Clouds[npos]; ok && (ocld != cld || g.GasDensity[npos] >= g.GasDensity[pos]) {
+			continue
+		}
+		if g.ClosedDoor(npos) {
+			continue
+		}
+		walls := g.Dungeon.WallAreaCount(area, npos, 1) + RandInt(3)
+		if walls < bestWalls {
+			best = npos
+			bestWalls = walls
+		}
+	}
+	return best, best != InvalidPos
+}
+
+// PushCloudAway moves the cloud at pos into a free neighbour, if possible,
+// for example when a wall appears there.
+func (g *game) PushCloudAway(pos position, ev event) {
+	cld, ok := g.Clouds[pos]
+	if !ok {
+		return
+	}
+	density := g.GasDensity[pos]
+	g.RemoveCloud(pos)
+	if !cld.Gas() {
+		return
+	}
+	for _, npos := range g.Dungeon.FreeNeighbors(pos) {
+		if npos == pos {
+			continue
+		}
+		if ocld, ok := g.Clouds[npos]; ok && ocld != cld {
+			continue
+		}
+		g.Gas(npos, cld, density, ev)
+		return
+	}
+}
+
+func (g *game) RemoveCloud(pos position) {
+	cld, ok := g.Clouds[pos]
+	if !ok {
+		return
+	}
+	delete(g.Clouds, pos)
+	delete(g.GasDensity, pos)
+	if cld.Gas() && g.Player.LOS[pos] {
+		g.DijkstraMapRebuild = true
+	}
+	if cld.Opaque() {
+		g.ComputeLOS()
+	}
+}
+
+func (g *game) GasCreature(pos position, cld cloud, ev event) {
+	mons := g.MonsterAt(pos)
+	if mons.Exists() {
+		switch cld {
+		case CloudPoison:
+			if mons.Kind.Living() {
+				mons.AddEffect(g, EffPoison, EffPoison.Duration())
+			}
+		case CloudSteam:
+			mons.HP -= 1 + RandInt(3)
+			if mons.HP <= 0 {
+				if mons.Visible(g) {
+					g.PrintfStyled("%s is killed by the steam.", logPlayerHit, mons.Kind.Definite(true))
+				}
+				g.HandleKill(mons, ev)
+			} else {
+				mons.MakeAwareIfHurt(g)
+			}
+		case CloudConfusion:
+			mons.EnterConfusion(g, ev)
+		}
+	}
+	if pos != g.Player.Pos {
+		return
+	}
+	switch cld {
+	case CloudPoison:
+		g.PlayerAddEffect(EffPoison, EffPoison.Duration())
+		g.StopAuto()
+	case CloudSteam:
+		damage := 1 + RandInt(3)
+		g.PrintfStyled("The steam scalds you (%d dmg).", logMonsterHit, damage)
+		g.DamagePlayer(damage)
+		g.StopAuto()
+	case CloudConfusion:
+		if !g.Player.Aptitudes[AptConfusingGas] {
+			g.Confusion(ev)
+			g.StopAuto()
+		}
+	}
+}
@@ -32,6 +32,14 @@ func (g *game) HitDamage(dt dmgType, base int, armor int) (attack int, clang boo
 	return attack, clang
 }
 
+// DamagePlayer inflicts damage to the player from a source other than a
+// monster attack, like gas or poison.
+func (g *game) DamagePlayer(damage int) {
+	g.Stats.Damage += damage
+	g.Player.HP -= damage
+	g.ui.WoundedAnimation()
+}
+
 func (m *monster) InflictDamage(g *game, damage, max int) {
 	g.Stats.ReceivedHits++
 	g.Stats.Damage += damage
@@ -76,7 +84,7 @@ func (m *monster) InflictDamage(g *game, damage, max int) {
 		//g.Confusion(g.Ev)
 		g.UseStone(g.Player.Pos)
 	case TreeStone:
-		if !g.Player.HasStatus(StatusLignification) {
+		if !g.Player.HasEffect(EffLignification) {
 			g.UseStone(g.Player.Pos)
 			g.EnterLignification(g.Ev)
 			g.Print("You feel rooted to the ground.")
@@ -111,6 +119,7 @@ func (g *game) MakeMonstersAware() {
 }
 
 func (g *game) MakeNoise(noise int, at position) {
+	g.LevelNoise += noise
 	dij := &normalPath{game: g}
 	nm := Dijkstra(dij, []position{at}, noise)
 	for _, m := range g.Monsters {
@@ -136,7 +145,7 @@ func (g *game) MakeNoise(noise int, at position) {
 		if m.State == Resting {
 			v /= 2
 		}
-		if m.Status(MonsExhausted) {
+		if m.HasEffect(EffExhausted) {
 			v = 2 * v / 3
 		}
 		if v > r {
@@ -166,7 +175,7 @@ func (g *game) InOpenMons(mons *monster) bool {
 
 func (g *game) AttackMonster(mons *monster, ev event) {
 	switch {
-	case g.Player.HasStatus(StatusSwap) && !g.Player.HasStatus(StatusLignification) && !mons.Status(MonsLignified):
+	case g.Player.HasEffect(EffSwap) && !g.Player.HasEffect(EffLignification) && !mons.HasEffect(EffLignification):
 		g.SwapWithMonster(mons)
 	case g.Player.Weapon == Frundis:
 		if !g.HitMonster(DmgPhysical, g.Player.Attack(), mons, ev) {
@@ -178,20 +187,20 @@ func (g *game) AttackMonster(mons *monster, ev event) {
 		}
 	case g.Player.Weapon.Cleave():
 		var neighbors []position
-		if g.Player.HasStatus(StatusConfusion) {
+		if g.Player.HasEffect(EffConfusion) {
 			neighbors = g.Dungeon.CardinalFreeNeighbors(g.Player.Pos)
 		} else {
 			neighbors = g.Dungeon.FreeNeighbors(g.Player.Pos)
 		}
 		for _, pos := range neighbors {
 			m := g.MonsterAt(pos)
-			if m.Exists() {
-				g.HitMonster(DmgPhysical, g.Player.Attack(), m, ev)
+			if m.Exists() && g.HitMonster(DmgPhysical, g.Player.Attack(), m, ev) {
+				g.WeaponKnockback(m, ev)
 			}
 		}
 	case g.Player.Weapon.Pierce():
-		g.HitMonster(DmgPhysical, g.Player.Attack(), mons, ev)
 		dir := mons.Pos.Dir(g.Player.Pos)
+		hit := g.HitMonster(DmgPhysical, g.Player.Attack(), mons, ev)
 		behind := g.Player.Pos.To(dir).To(dir)
 		if behind.valid() {
 			m := g.MonsterAt(behind)
@@ -199,12 +208,15 @@ func (g *game) AttackMonster(mons *monster, ev event) {
 				g.HitMonster(DmgPhysical, g.Player.Attack(), m, ev)
 			}
 		}
+		if hit {
+			g.WeaponKnockback(mons, ev)
+		}
 	case g.Player.Weapon == ElecWhip:
 		g.HitConnected(mons.Pos, DmgMagical, ev)
 	case g.Player.Weapon == DancingRapier:
 		ompos := mons.Pos
 		g.HitMonster(DmgPhysical, g.Player.Attack(), mons, ev)
-		if g.Player.HasStatus(StatusLignification) || mons.Status(MonsLignified) || mons.Kind == MonsTinyHarpy {
+		if g.Player.HasEffect(EffLignification) || mons.HasEffect(EffLignification) || mons.Kind == MonsTinyHarpy {
 			break
 		}
 		dir := ompos.Dir(g.Player.Pos)
@@ -238,10 +250,9 @@ func (g *game) AttackMonster(mons *monster, ev event) {
 		bonus := -1 + 13*mfact/100
 		g.HitMonster(DmgPhysical, g.Player.Attack()+bonus, mons, ev)
 	case g.Player.Weapon == DefenderFlail:
-		bonus := g.Player.Statuses[StatusSlay]
+		bonus := g.Player.Effects.Intensity(EffSlay)
 		g.HitMonster(DmgPhysical, g.Player.Attack()+bonus, mons, ev)
-		g.Player.Statuses[StatusSlay]++
-		g.PushEvent(&simpleEvent{ERank: ev.Rank() + 60, EAction: SlayEnd})
+		g.PlayerAddEffect(EffSlay, EffSlay.Duration())
 	default:
 		g.HitMonster(DmgPhysical, g.Player.Attack(), mons, ev)
 	}
@@ -273,7 +284,7 @@ func (g *game) HarKarAttack(mons *monster, ev event) {
 			break
 		}
 	}
-	if pos.valid() && g.Dungeon.Cell(pos).T == FreeCell && !g.Player.HasStatus(StatusLignification) {
+	if pos.valid() && g.Dungeon.Cell(pos).T == FreeCell && !g.Player.HasEffect(EffLignification) {
 		pos = g.Player.Pos
 		for {
 			pos = pos.To(dir)
@@ -380,7 +391,7 @@ func (g *game) HitMonster(dt dmgType, dmg int, mons *monster, ev event) (hit boo
 	if mons.State == Resting {
 		evasion /= 2 + 1
 	}
-	if acc > evasion || g.Player.HasStatus(StatusAccurate) {
+	if acc > evasion || g.Player.HasEffect(EffAccurate) {
 		hit = true
 		noise := BaseHitNoise
 		if g.Player.Weapon == Dagger || g.Player.Weapon == VampDagger {
@@ -393,7 +404,7 @@ func (g *game) HitMonster(dt dmgType, dmg int, mons *monster, ev event) (hit boo
 			noise -= 5
 		}
 		bonus := 0
-		if g.Player.HasStatus(StatusBerserk) {
+		if g.Player.HasEffect(EffBerserk) {
 			bonus += 2 + RandInt(4)
 		}
 		pa := dmg + bonus
@@ -452,15 +463,7 @@ func (g *game) HitMonster(dt dmgType, dmg int, mons *monster, ev event) (hit boo
 			g.PrintfStyled("You kill %s (%d dmg).%s", logPlayerHit, mons.Kind.Definite(false), attack, sclang)
 			g.HandleKill(mons, ev)
 		}
-		if mons.Kind == MonsBrizzia && RandInt(4) == 0 && !g.Player.HasStatus(StatusNausea) &&
-			mons.Pos.Distance(g.Player.Pos) == 1 {
-			g.Player.Statuses[StatusNausea]++
-			g.PushEvent(&simpleEvent{ERank: ev.Rank() + 30 + RandInt(20), EAction: NauseaEnd})
-			g.Print("The brizzia's corpse releases some nauseating gas. You feel sick.")
-		}
-		if mons.Kind == MonsTinyHarpy && mons.HP > 0 {
-			mons.Blink(g)
-		}
+		mons.TriggerAbilities(g, ev, TriggerHit)
 		g.HandleStone(mons)
 		g.Stats.Hits++
 	} else {
@@ -536,13 +539,24 @@ func (g *game) HandleStone(mons *monster) {
 func (g *game) HandleKill(mons *monster, ev event) {
 	g.Stats.Killed++
 	g.Stats.KilledMons[mons.Kind]++
-	if mons.Kind == MonsExplosiveNadre {
+	if mons.Champion() {
+		g.Stats.KilledChampions[mons.Kind]++
+	}
+	mons.TriggerAbilities(g, ev, TriggerDeath)
+	if mons.HasMod(ChampExplosive) {
 		mons.Explode(g, ev)
 	}
-	if g.Doors[mons.Pos] {
+	mons.DropItems(g)
+	if g.ClosedDoor(mons.Pos) {
 		g.ComputeLOS()
 	}
-	if mons.Kind.Dangerousness() > 10 {
+	g.GhostKilled(mons)
+	if g.BossKilled(mons) {
+		return
+	}
+	if mons.Champion() {
+		g.StoryPrintf("Killed %s.", Indefinite(mons.Name(), false))
+	} else if mons.Kind.Dangerousness() > 10 {
 		g.StoryPrintf("Killed %s.", mons.Kind.Indefinite(false))
 	}
 }
@@ -0,0 +1,113 @@
+package main
+
+type difficulty int
+
+const (
+	NormalDifficulty difficulty = iota
+	EasyDifficulty
+	HardDifficulty
+)
+
+var Difficulties = []difficulty{EasyDifficulty, NormalDifficulty, HardDifficulty}
+
+func (d difficulty) String() (text string) {
+	switch d {
+	case EasyDifficulty:
+		text = "easy"
+	case NormalDifficulty:
+		text = "normal"
+	case HardDifficulty:
+		text = "hard"
+	}
+	return text
+}
+
+func (d difficulty) Desc() (text string) {
+	switch d {
+	case EasyDifficulty:
+		text = "Fewer monsters, more items, faster regeneration and rod recharge."
+	case NormalDifficulty:
+		text = "The game as it was designed."
+	case HardDifficulty:
+		text = "More monsters, fewer items, slower regeneration and rod recharge."
+	}
+	return text
+}
+
+func (d difficulty) DangerPercent() int {
+	switch d {
+	case EasyDifficulty:
+		return 85
+	case HardDifficulty:
+		return 115
+	}
+	return 100
+}
+
+func (d difficulty) MonstersPercent() int {
+	switch d {
+	case EasyDifficulty:
+		return 90
+	case HardDifficulty:
+		return 110
+	}
+	return 100
+}
+
+func (d difficulty) HealDelay() int {
+	switch d {
+	case EasyDifficulty:
+		return 40
+	case HardDifficulty:
+		return 60
+	}
+	return 50
+}
+
+func (d difficulty) MPRegenDelay() int {
+	switch d {
+	case EasyDifficulty:
+		return 80
+	case HardDifficulty:
+		return 120
+	}
+	return 100
+}
+
+// ReinforcementDelay is the number of turns after which new monsters
+// arrive on a level.
+func (d difficulty) ReinforcementDelay() int {
+	switch d {
+	case EasyDifficulty:
+		return 1500
+	case HardDifficulty:
+		return 700
+	}
+	return 1000
+}
+
+// CollectablesPercent scales the expected number of collectables generated
+// per level.
+func (d difficulty) CollectablesPercent() int {
+	switch d {
+	case EasyDifficulty:
+		return 110
+	case HardDifficulty:
+		return 80
+	}
+	return 100
+}
+
+func (d difficulty) RechargeAdjust() int {
+	switch d {
+	case EasyDifficulty:
+		if RandInt(2) == 0 {
+			return 1
+		}
+	case HardDifficulty:
+		if RandInt(3) == 0 {
+			return -1
+		}
+	}
+	return 0
+}
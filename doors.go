@@ -0,0 +1,201 @@
+package main
+
+import "errors"
+
+type door int
+
+const (
+	DoorClosed door = iota
+	DoorOpen
+	DoorLocked
+)
+
+func (d door) String() (text string) {
+	switch d {
+	case DoorClosed:
+		text = "closed door"
+	case DoorOpen:
+		text = "open door"
+	case DoorLocked:
+		text = "locked door"
+	}
+	return text
+}
+
+func (d door) Letter() rune {
+	if d == DoorOpen {
+		return '\''
+	}
+	return '+'
+}
+
+func (d door) Desc() (text string) {
+	switch d {
+	case DoorClosed:
+		text = "A closed door blocks your line of sight. You open doors by walking into them, and some monsters can open them too. Doors are flammable."
+	case DoorOpen:
+		text = "An open door does not block your line of sight. You can close it again if nothing stands in the way. Doors are flammable."
+	case DoorLocked:
+		text = "A locked door closes a vault. You need a key found somewhere on the level to open it. Doors are flammable."
+	}
+	return text
+}
+
+func RandomDoorState() door {
+	if RandInt(4) == 0 {
+		return DoorOpen
+	}
+	return DoorClosed
+}
+
+func (g *game) IsDoor(pos position) bool {
+	_, ok := g.Doors[pos]
+	return ok
+}
+
+func (g *game) ClosedDoor(pos position) bool {
+	d, ok := g.Doors[pos]
+	return ok && d != DoorOpen
+}
+
+func (g *game) LockedDoorBlocks(pos position) bool {
+	d, ok := g.Doors[pos]
+	return ok && d == DoorLocked && g.Player.Keys == 0
+}
+
+func (g *game) OpenDoor(pos position) {
+	if d, ok := g.Doors[pos]; ok && d == DoorClosed {
+		g.Doors[pos] = DoorOpen
+	}
+}
+
+func (mk monsterKind) CanOpenDoors() bool {
+	switch mk {
+	case MonsGoblin, MonsOgre, MonsCyclop, MonsGoblinWarrior, MonsSkeletonWarrior,
+		MonsLich, MonsEarthDragon, MonsMirrorSpecter, MonsMadNixe, MonsMindCelmist,
+		MonsVampire, MonsMarevorHelith, MonsGoblinWarlord, MonsEarthShaper:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *monster) CanPass(g *game, pos position) bool {
+	d, ok := g.Doors[pos]
+	if !ok {
+		return true
+	}
+	switch d {
+	case DoorOpen:
+		return true
+	case DoorClosed:
+		return m.Kind.CanOpenDoors()
+	}
+	return false
+}
+
+func (g *game) CloseDoor(ev event) error {
+	closed := 0
+	blocked := false
+	for _, pos := range g.Dungeon.FreeNeighbors(g.Player.Pos) {
+		if d, ok := g.Doors[pos]; !ok || d != DoorOpen {
+			continue
+		}
+		if g.MonsterAt(pos).Exists() || g.ObjectAt(pos) {
+			blocked = true
+			continue
+		}
+		g.Doors[pos] = DoorClosed
+		closed++
+	}
+	switch {
+	case closed == 1:
+		g.Print("You close the door.")
+	case closed > 1:
+		g.Print("You close the doors.")
+	case blocked:
+		return errors.New("Something blocks the door.")
+	default:
+		return errors.New("There is no open door next to you.")
+	}
+	g.ComputeLOS()
+	ev.Renew(g, 10)
+	return nil
+}
+
+func (g *game) ObjectAt(pos position) bool {
+	if _, ok := g.Collectables[pos]; ok {
+		return true
+	}
+	if _, ok := g.Equipables[pos]; ok {
+		return true
+	}
+	if _, ok := g.Rods[pos]; ok {
+		return true
+	}
+	if g.Keys[pos] {
+		return true
+	}
+	return false
+}
+
+func (g *game) UnlockDoor(pos position, ev event) error {
+	if g.Player.Keys == 0 {
+		return errors.New("This door is locked. You need a key.")
+	}
+	g.Player.Keys--
+	g.Doors[pos] = DoorOpen
+	g.Print("You unlock the door.")
+	g.StoryPrint("Unlocked a vault")
+	g.ComputeLOS()
+	ev.Renew(g, 10)
+	return nil
+}
+
+// GenVault locks a door that is the only access to a small area, and puts
+// a key somewhere else on the level. It returns the vault positions.
+func (g *game) GenVault() []position {
+	d := g.Dungeon
+	for dpos := range g.Doors {
+		for _, npos := range d.FreeNeighbors(dpos) {
+			if _, ok := g.Doors[npos]; ok {
+				continue
+			}
+			conn, count := d.Connected(npos, func(pos position) bool {
+				return pos != dpos && d.IsFreeCell(pos)
+			})
+			if count < 8 || count > 60 || conn[g.Player.Pos] {
+				continue
+			}
+			g.Doors[dpos] = DoorLocked
+			for {
+				pos := g.FreeCellForStatic()
+				if !conn[pos] {
+					g.Keys[pos] = true
+					break
+				}
+			}
+			vault := []position{}
+			for pos := range conn {
+				vault = append(vault, pos)
+			}
+			return vault
+		}
+	}
+	return nil
+}
+
+func (g *game) GenVaultTreasure(vault []position) {
+	for i := 0; i < 100; i++ {
+		pos := vault[RandInt(len(vault))]
+		if g.ObjectAt(pos) || g.Simellas[pos] > 0 || g.MonsterAt(pos).Exists() {
+			continue
+		}
+		if _, ok := g.Doors[pos]; ok {
+			continue
+		}
+		c := g.RandomCollectable()
+		g.Collectables[pos] = collectable{Consumable: c, Quantity: ConsumablesCollectData[c].quantity}
+		return
+	}
+}
@@ -159,6 +159,7 @@ var (
 	ColorBgLOS,
 	ColorFg,
 	ColorFgAnimationHit,
+	ColorFgChampion,
 	ColorFgCollectable,
 	ColorFgConfusedMonster,
 	ColorFgLignifiedMonster,
@@ -180,9 +181,11 @@ var (
 	ColorFgMonster,
 	ColorFgPlace,
 	ColorFgPlayer,
+	ColorFgPoisonGas,
 	ColorFgProjectile,
 	ColorFgSimellas,
 	ColorFgSleepingMonster,
+	ColorFgSteam,
 	ColorFgStatusBad,
 	ColorFgStatusGood,
 	ColorFgStatusExpire,
@@ -200,6 +203,7 @@ func LinkColors() {
 	ColorFgDark = ColorBase01
 	ColorFgLOS = ColorBase0
 	ColorFgAnimationHit = ColorMagenta
+	ColorFgChampion = ColorMagenta
 	ColorFgCollectable = ColorYellow
 	ColorFgConfusedMonster = ColorGreen
 	ColorFgLignifiedMonster = ColorYellow
@@ -219,9 +223,11 @@ func LinkColors() {
 	ColorFgMonster = ColorRed
 	ColorFgPlace = ColorMagenta
 	ColorFgPlayer = ColorBlue
+	ColorFgPoisonGas = ColorGreen
 	ColorFgProjectile = ColorBlue
 	ColorFgSimellas = ColorYellow
 	ColorFgSleepingMonster = ColorViolet
+	ColorFgSteam = ColorBase1
 	ColorFgStatusBad = ColorRed
 	ColorFgStatusGood = ColorBlue
 	ColorFgStatusExpire = ColorViolet
@@ -338,6 +344,9 @@ func Simple8ColorPalette() {
 type drawFrame struct {
 	Draws []cellDraw
 	Time  time.Time
+	Turn  int
+	Depth int
+	Mark  frameMark
 }
 
 type cellDraw struct {
@@ -363,11 +372,15 @@ func (ui *gameui) SetMapCell(x, y int, r rune, fg, bg uicolor) {
 	ui.SetGenCell(x, y, r, fg, bg, true)
 }
 
+// FrameListener, if not nil, receives every new frame, as used by the
+// spectator server.
+var FrameListener func(drawFrame)
+
 func (ui *gameui) DrawLogFrame() {
 	if len(ui.g.drawBackBuffer) != len(ui.g.DrawBuffer) {
 		ui.g.drawBackBuffer = make([]UICell, len(ui.g.DrawBuffer))
 	}
-	ui.g.DrawLog = append(ui.g.DrawLog, drawFrame{Time: time.Now()})
+	ui.g.DrawLog = append(ui.g.DrawLog, drawFrame{Time: time.Now(), Turn: ui.g.Turn / 10, Depth: ui.g.Depth})
 	for i := 0; i < len(ui.g.DrawBuffer); i++ {
 		if ui.g.DrawBuffer[i] == ui.g.drawBackBuffer[i] {
 			continue
@@ -379,6 +392,9 @@ func (ui *gameui) DrawLogFrame() {
 		ui.g.DrawLog[last].Draws = append(ui.g.DrawLog[last].Draws, cdraw)
 		ui.g.drawBackBuffer[i] = c
 	}
+	if FrameListener != nil {
+		FrameListener(ui.g.DrawLog[len(ui.g.DrawLog)-1])
+	}
 }
 
 func (ui *gameui) DrawWelcomeCommon() int {
@@ -532,6 +548,7 @@ func (ui *gameui) KeysHelp() {
 		"Rest (until status free or regen)", "r",
 		"Descend stairs", "> or D",
 		"Go to nearest stairs", "G",
+		"Travel to a landmark", "T",
 		"Autoexplore", "o",
 		"Examine", "x or mouse left",
 		"Equip/Get weapon/armour/...", "e or g",
@@ -539,6 +556,9 @@ func (ui *gameui) KeysHelp() {
 		"Throw/Fire item", "t or f",
 		"Evoke/Zap rod", "v or z",
 		"Inventory summary", `i`,
+		"Close door", "c",
+		"Shove adjacent monster", "p",
+		"Auto-fight nearest monster", "F",
 		"View Character and Quest Information", `% or C`,
 		"View previous messages", "m",
 		"Write game statistics to file", "#",
@@ -557,6 +577,7 @@ func (ui *gameui) ExamineHelp() {
 		"Go to/select target", "“.” or enter or mouse left",
 		"View target description", "v or d or mouse right",
 		"Toggle exclude area from auto-travel", "e or mouse middle",
+		"Annotate position", "a",
 	})
 }
 
@@ -569,6 +590,8 @@ func (ui *gameui) CharacterInfo() {
 	b := bytes.Buffer{}
 	b.WriteString(formatText("Every year, the elders send someone to collect medicinal simella plants in the Underground.  This year, the honor fell upon you, and so here you are.  According to the elders, deep in the Underground, a magical monolith will lead you back to your village.", TextWidth))
 	b.WriteString("\n\n")
+	b.WriteString(formatText(fmt.Sprintf("You are %s. %s", Indefinite(g.Player.Background.String(), false), g.Player.Background.Desc()), TextWidth))
+	b.WriteString("\n\n")
 	b.WriteString(formatText(
 		fmt.Sprintf("You are wielding %s. %s", Indefinite(g.Player.Weapon.String(), false), g.Player.Weapon.Desc()), TextWidth))
 	b.WriteString("\n\n")
@@ -607,6 +630,7 @@ func (ui *gameui) WizardInfo() {
 	b := &bytes.Buffer{}
 	fmt.Fprintf(b, "Monsters: %d (%d)\n", len(g.Monsters), g.MaxMonsters())
 	fmt.Fprintf(b, "Danger: %d (%d)\n", g.Danger(), g.MaxDanger())
+	fmt.Fprintf(b, "Reinforcements: %d (%d)\n", g.ReinforcementTime(), g.ReinforcementTurn)
 	ui.DrawText(b.String(), 0, 0)
 	ui.Flush()
 	ui.WaitForContinue(-1)
@@ -666,7 +690,7 @@ func (ui *gameui) DescribePosition(pos position, targ Targeter) {
 		g.InfoEntry = desc + "."
 		return
 	}
-	if mons.Exists() && g.Player.LOS[pos] {
+	if mons.Visible(g) {
 		desc = ui.AddComma(see, desc)
 		desc += fmt.Sprintf("%s (%s)", mons.Kind.Indefinite(false), ui.MonsterInfo(mons))
 	}
@@ -676,6 +700,9 @@ func (ui *gameui) DescribePosition(pos position, targ Targeter) {
 	case g.Simellas[pos] > 0:
 		desc = ui.AddComma(see, desc)
 		desc += fmt.Sprintf("some simellas (%d)", g.Simellas[pos])
+	case g.Keys[pos]:
+		desc = ui.AddComma(see, desc)
+		desc += "a key"
 	case okCollectable:
 		if c.Quantity > 1 {
 			desc = ui.AddComma(see, desc)
@@ -701,21 +728,17 @@ func (ui *gameui) DescribePosition(pos position, targ Targeter) {
 	case okStone:
 		desc = ui.AddComma(see, desc)
 		desc += fmt.Sprint(Indefinite(stn.String(), false))
-	case g.Doors[pos] || g.WrongDoor[pos]:
+	case g.IsDoor(pos) || g.WrongDoor[pos]:
 		desc = ui.AddComma(see, desc)
-		desc += fmt.Sprintf("a door")
-	}
-	if cld, ok := g.Clouds[pos]; ok && g.Player.LOS[pos] {
-		if cld == CloudFire {
-			desc = ui.AddComma(see, desc)
-			desc += fmt.Sprintf("burning flames")
-		} else if cld == CloudNight {
-			desc = ui.AddComma(see, desc)
-			desc += fmt.Sprintf("night clouds")
+		if d, ok := g.Doors[pos]; ok {
+			desc += Indefinite(d.String(), false)
 		} else {
-			desc = ui.AddComma(see, desc)
-			desc += fmt.Sprintf("a dense fog")
+			desc += fmt.Sprintf("a door")
 		}
+	}
+	if cld, ok := g.Clouds[pos]; ok && g.Player.LOS[pos] {
+		desc = ui.AddComma(see, desc)
+		desc += cld.String()
 	} else if _, ok := g.Fungus[pos]; ok && !g.WrongFoliage[pos] || !ok && g.WrongFoliage[pos] {
 		desc = ui.AddComma(see, desc)
 		desc += fmt.Sprintf("foliage")
@@ -724,6 +747,9 @@ func (ui *gameui) DescribePosition(pos position, targ Targeter) {
 		desc += fmt.Sprintf("the ground")
 	}
 	g.InfoEntry = desc + "."
+	if note, ok := g.Annotations[pos]; ok {
+		g.InfoEntry += fmt.Sprintf(" Note: %s", note)
+	}
 }
 
 func (ui *gameui) ViewPositionDescription(pos position) {
@@ -733,7 +759,7 @@ func (ui *gameui) ViewPositionDescription(pos position) {
 		return
 	}
 	mons := g.MonsterAt(pos)
-	if mons.Exists() && g.Player.LOS[mons.Pos] {
+	if mons.Visible(g) {
 		ui.HideCursor()
 		ui.DrawMonsterDescription(mons)
 		ui.SetCursor(pos)
@@ -759,12 +785,14 @@ func (ui *gameui) ViewPositionDescription(pos position) {
 		}
 	} else if stn, ok := g.MagicalStones[pos]; ok {
 		ui.DrawDescription(stn.Description())
-	} else if g.Doors[pos] {
-		ui.DrawDescription("A closed door blocks your line of sight. Doors open automatically when you or a monster stand on them. Doors are flammable.")
+	} else if d, ok := g.Doors[pos]; ok {
+		ui.DrawDescription(d.Desc())
+	} else if g.Keys[pos] {
+		ui.DrawDescription("A key opens a locked vault door on this level. Keys are of no use in other levels.")
 	} else if g.Simellas[pos] > 0 {
 		ui.DrawDescription("A simella is a plant with big white flowers which are used in the Underground for their medicinal properties. They can also make tasty infusions. You were actually sent here by your village to collect as many as possible of those plants.")
 	} else if _, ok := g.Fungus[pos]; ok && g.Dungeon.Cell(pos).T == FreeCell {
-		ui.DrawDescription("Blue dense foliage grows in the Underground. It is difficult to see through, and is flammable.")
+		ui.DrawDescription("Blue dense foliage grows in the Underground. It is difficult to see through, and is flammable. Over time, it regrows where it burnt and slowly spreads.")
 	} else if g.Dungeon.Cell(pos).T == WallCell {
 		ui.DrawDescription("A wall is an impassable pile of rocks. It can be destructed by using some items.")
 	} else {
@@ -778,15 +806,19 @@ func (ui *gameui) MonsterInfo(m *monster) string {
 	if m.Kind == MonsSatowalgaPlant && m.State == Wandering {
 		state = "awaken"
 	}
+	if m.Champion() {
+		infos = append(infos, m.ModsText()+" champion")
+	}
 	infos = append(infos, state)
-	for st, i := range m.Statuses {
-		if i > 0 {
-			infos = append(infos, monsterStatus(st).String())
-		}
+	for _, e := range m.Effects.Sorted() {
+		infos = append(infos, strings.ToLower(m.Effects.Text(e)))
 	}
 	p := (m.HP * 100) / m.HPmax
 	health := fmt.Sprintf("%d %% HP", p)
 	infos = append(infos, health)
+	if len(m.Items) > 0 || len(m.Gear) > 0 || len(m.Rods) > 0 {
+		infos = append(infos, "carrying "+m.ItemsText())
+	}
 	return strings.Join(infos, ", ")
 }
 
@@ -959,6 +991,12 @@ func (ui *gameui) PositionDrawing(pos position) (r rune, fgColor, bgColor uicolo
 				fgColor = ColorFgWanderingMonster
 			} else if cld == CloudNight {
 				fgColor = ColorFgSleepingMonster
+			} else if cld == CloudPoison {
+				fgColor = ColorFgPoisonGas
+			} else if cld == CloudSteam {
+				fgColor = ColorFgSteam
+			} else if cld == CloudConfusion {
+				fgColor = ColorFgMagicPlace
 			}
 		}
 		if c, ok := g.Collectables[pos]; ok {
@@ -988,20 +1026,31 @@ func (ui *gameui) PositionDrawing(pos position) (r rune, fgColor, bgColor uicolo
 		} else if _, ok := g.Simellas[pos]; ok {
 			r = '♣'
 			fgColor = ColorFgSimellas
-		} else if _, ok := g.Doors[pos]; ok {
-			r = '+'
+		} else if d, ok := g.Doors[pos]; ok {
+			r = d.Letter()
+			fgColor = ColorFgPlace
+			if d == DoorLocked {
+				fgColor = ColorFgExcluded
+			}
+		} else if _, ok := g.Keys[pos]; ok {
+			r = '-'
+			fgColor = ColorFgCollectable
+		} else if _, ok := g.Annotations[pos]; ok {
+			r = '×'
 			fgColor = ColorFgPlace
 		}
 		if (g.Player.LOS[pos] || g.Wizard) && !g.WizardMap {
 			m := g.MonsterAt(pos)
-			if m.Exists() {
+			if m.Exists() && (g.Wizard || !m.Invisible()) {
 				r = m.Kind.Letter()
-				if m.Status(MonsLignified) {
+				if m.HasEffect(EffLignification) {
 					fgColor = ColorFgLignifiedMonster
-				} else if m.Status(MonsConfused) {
+				} else if m.HasEffect(EffConfusion) {
 					fgColor = ColorFgConfusedMonster
-				} else if m.Status(MonsSlow) {
+				} else if m.HasEffect(EffSlow) {
 					fgColor = ColorFgSlowedMonster
+				} else if m.Champion() {
+					fgColor = ColorFgChampion
 				} else if m.State == Resting {
 					fgColor = ColorFgSleepingMonster
 				} else if m.State == Wandering {
@@ -1009,6 +1058,9 @@ func (ui *gameui) PositionDrawing(pos position) (r rune, fgColor, bgColor uicolo
 				} else {
 					fgColor = ColorFgMonster
 				}
+			} else if !g.Wizard && g.Noise[pos] {
+				r = '♫'
+				fgColor = ColorFgWanderingMonster
 			}
 		} else if !g.Wizard && g.Noise[pos] {
 			r = '♫'
@@ -1023,19 +1075,7 @@ func (ui *gameui) PositionDrawing(pos position) (r rune, fgColor, bgColor uicolo
 
 func (ui *gameui) DrawStatusBar(line int) {
 	g := ui.g
-	sts := statusSlice{}
-	if cld, ok := g.Clouds[g.Player.Pos]; ok && cld == CloudFire {
-		g.Player.Statuses[StatusFlames] = 1
-		defer func() {
-			g.Player.Statuses[StatusFlames] = 0
-		}()
-	}
-	for st, c := range g.Player.Statuses {
-		if c > 0 {
-			sts = append(sts, st)
-		}
-	}
-	sort.Sort(sts)
+	effs := ui.PlayerStatusEffects()
 	hpColor := ColorFgHPok
 	switch {
 	case g.Player.HP*100/g.Player.HPMax() < 30:
@@ -1057,6 +1097,10 @@ func (ui *gameui) DrawStatusBar(line int) {
 	line++
 	ui.DrawText(fmt.Sprintf("Simellas: %d", g.Player.Simellas), BarCol, line)
 	line++
+	if g.Player.Keys > 0 {
+		ui.DrawText(fmt.Sprintf("Keys: %d", g.Player.Keys), BarCol, line)
+		line++
+	}
 	if g.Depth == -1 {
 		ui.DrawText("Depth: Out!", BarCol, line)
 	} else {
@@ -1065,47 +1109,19 @@ func (ui *gameui) DrawStatusBar(line int) {
 	line++
 	ui.DrawText(fmt.Sprintf("Turns: %.1f", float64(g.Turn)/10), BarCol, line)
 	line++
-	for _, st := range sts {
-		fg := ColorFgStatusOther
-		if st.Good() {
-			fg = ColorFgStatusGood
-			t := 13
-			if g.Player.Statuses[StatusBerserk] > 0 {
-				t -= 3
-			}
-			if g.Player.Statuses[StatusSlow] > 0 {
-				t += 3
-			}
-			if g.Player.Expire[st] >= g.Ev.Rank() && g.Player.Expire[st]-g.Ev.Rank() <= t {
-				fg = ColorFgStatusExpire
-			}
-		} else if st.Bad() {
-			fg = ColorFgStatusBad
-		}
-		if g.Player.Statuses[st] > 1 {
-			ui.DrawColoredText(fmt.Sprintf("%s(%d)", st, g.Player.Statuses[st]), BarCol, line, fg)
-		} else {
-			ui.DrawColoredText(st.String(), BarCol, line, fg)
-		}
+	if g.Opts.Difficulty != NormalDifficulty {
+		ui.DrawColoredText(fmt.Sprintf("Mode: %s", g.Opts.Difficulty), BarCol, line, ui.DifficultyColor())
+		line++
+	}
+	for _, e := range effs {
+		ui.DrawColoredText(g.Player.Effects.Text(e), BarCol, line, ui.EffectColor(e))
 		line++
 	}
 }
 
 func (ui *gameui) DrawStatusLine() {
 	g := ui.g
-	sts := statusSlice{}
-	if cld, ok := g.Clouds[g.Player.Pos]; ok && cld == CloudFire {
-		g.Player.Statuses[StatusFlames] = 1
-		defer func() {
-			g.Player.Statuses[StatusFlames] = 0
-		}()
-	}
-	for st, c := range g.Player.Statuses {
-		if c > 0 {
-			sts = append(sts, st)
-		}
-	}
-	sort.Sort(sts)
+	effs := ui.PlayerStatusEffects()
 	hpColor := ColorFgHPok
 	switch {
 	case g.Player.HP*100/g.Player.HPMax() < 30:
@@ -1146,6 +1162,11 @@ func (ui *gameui) DrawStatusLine() {
 	simellas := fmt.Sprintf(":%d ", g.Player.Simellas)
 	ui.DrawText(simellas, col, line)
 	col += utf8.RuneCountInString(simellas)
+	if g.Player.Keys > 0 {
+		keys := fmt.Sprintf("K:%d ", g.Player.Keys)
+		ui.DrawText(keys, col, line)
+		col += utf8.RuneCountInString(keys)
+	}
 	var depth string
 	if g.Depth == -1 {
 		depth = "D: Out! "
@@ -1157,41 +1178,64 @@ func (ui *gameui) DrawStatusLine() {
 	turns := fmt.Sprintf("T:%.1f ", float64(g.Turn)/10)
 	ui.DrawText(turns, col, line)
 	col += utf8.RuneCountInString(turns)
+	if g.Opts.Difficulty != NormalDifficulty {
+		mode := fmt.Sprintf("%s ", strings.Title(g.Opts.Difficulty.String()))
+		ui.DrawColoredText(mode, col, line, ui.DifficultyColor())
+		col += utf8.RuneCountInString(mode)
+	}
 	hp := fmt.Sprintf("HP:%2d ", g.Player.HP)
 	ui.DrawColoredText(hp, col, line, hpColor)
 	col += utf8.RuneCountInString(hp)
 	mp := fmt.Sprintf("MP:%d ", g.Player.MP)
 	ui.DrawColoredText(mp, col, line, mpColor)
 	col += utf8.RuneCountInString(mp)
-	if len(sts) > 0 {
+	if len(effs) > 0 {
 		ui.DrawText("| ", col, line)
 		col += 2
 	}
-	for _, st := range sts {
-		fg := ColorFgStatusOther
-		if st.Good() {
-			fg = ColorFgStatusGood
-			t := 13
-			if g.Player.Statuses[StatusBerserk] > 0 {
-				t -= 3
-			}
-			if g.Player.Statuses[StatusSlow] > 0 {
-				t += 3
-			}
-			if g.Player.Expire[st] >= g.Ev.Rank() && g.Player.Expire[st]-g.Ev.Rank() <= t {
-				fg = ColorFgStatusExpire
-			}
-		} else if st.Bad() {
-			fg = ColorFgStatusBad
+	for _, e := range effs {
+		etext := fmt.Sprintf("%s ", g.Player.Effects.ShortText(e))
+		ui.DrawColoredText(etext, col, line, ui.EffectColor(e))
+		col += utf8.RuneCountInString(etext)
+	}
+}
+
+// PlayerStatusEffects returns the player's effects to display, including
+// flames when standing in fire.
+func (ui *gameui) PlayerStatusEffects() []effect {
+	g := ui.g
+	cld, ok := g.Clouds[g.Player.Pos]
+	burning := ok && cld == CloudFire
+	effs := []effect{}
+	for i := 0; i < NumEffects; i++ {
+		e := effect(i)
+		if g.Player.HasEffect(e) || e == EffFlames && burning {
+			effs = append(effs, e)
 		}
-		var sttext string
-		if g.Player.Statuses[st] > 1 {
-			sttext = fmt.Sprintf("%s(%d) ", st.Short(), g.Player.Statuses[st])
-		} else {
-			sttext = fmt.Sprintf("%s ", st.Short())
+	}
+	return effs
+}
+
+func (ui *gameui) EffectColor(e effect) uicolor {
+	g := ui.g
+	switch {
+	case e.Good():
+		t := 13
+		if g.Player.HasEffect(EffBerserk) {
+			t -= 3
+		}
+		if g.Player.HasEffect(EffSlow) {
+			t += 3
 		}
-		ui.DrawColoredText(sttext, col, line, fg)
-		col += utf8.RuneCountInString(sttext)
+		expire := g.Player.Effects[e].Expire
+		if expire >= g.Ev.Rank() && expire-g.Ev.Rank() <= t {
+			return ColorFgStatusExpire
+		}
+		return ColorFgStatusGood
+	case e.Bad():
+		return ColorFgStatusBad
+	default:
+		return ColorFgStatusOther
 	}
 }
 
@@ -1399,11 +1443,16 @@ func (ui *gameui) DrawPreviousLogs() {
 		bottom = 2
 	}
 	lines := DungeonHeight + bottom
-	nmax := len(g.Log) - lines
-	n := nmax
+	filter := LogFilterAll
+	search := ""
+	status := ""
+	stamps := false
+	log := g.Log
+	n := len(log) - lines
 loop:
 	for {
 		ui.DrawDungeonView(NoFlushMode)
+		nmax := len(log) - lines
 		if n >= nmax {
 			n = nmax
 		}
@@ -1411,17 +1460,22 @@ loop:
 			n = 0
 		}
 		to := n + lines
-		if to >= len(g.Log) {
-			to = len(g.Log)
+		if to >= len(log) {
+			to = len(log)
 		}
 		for i := 0; i < bottom; i++ {
 			ui.SetCell(DungeonWidth, DungeonHeight+i, '│', ColorFg, ColorBg)
 		}
 		for i := n; i < to; i++ {
-			e := g.Log[i]
+			e := log[i]
 			fguicolor := ui.LogColor(e)
 			ui.ClearLine(i - n)
-			rc := utf8.RuneCountInString(e.String())
+			col := 0
+			if stamps {
+				ui.DrawColoredText(e.Stamp(), 0, i-n, ColorFgPlace)
+				col = utf8.RuneCountInString(e.Stamp()) + 1
+			}
+			rc := col + utf8.RuneCountInString(e.String())
 			if e.Tick {
 				rc += 2
 			}
@@ -1431,23 +1485,75 @@ loop:
 				}
 			}
 			if e.Tick {
-				ui.DrawColoredText("•", 0, i-n, ColorYellow)
-				ui.DrawColoredText(e.String(), 2, i-n, fguicolor)
-			} else {
-				ui.DrawColoredText(e.String(), 0, i-n, fguicolor)
+				ui.DrawColoredText("•", col, i-n, ColorYellow)
+				col += 2
 			}
+			ui.DrawColoredText(e.String(), col, i-n, fguicolor)
 		}
-		for i := len(g.Log); i < DungeonHeight+bottom; i++ {
+		for i := len(log); i < DungeonHeight+bottom; i++ {
 			ui.ClearLine(i - n)
 		}
 		ui.ClearLine(lines)
-		s := fmt.Sprintf(" half-page up/down (u/d) quit (x) — (%d/%d) \n", len(g.Log)-to, len(g.Log))
+		var s string
+		switch {
+		case status != "":
+			s = fmt.Sprintf(" %s — (%d/%d) \n", status, len(log)-to, len(log))
+			status = ""
+		case filter != LogFilterAll || search != "":
+			s = fmt.Sprintf(" %s", filter)
+			if search != "" {
+				if utf8.RuneCountInString(search) > 20 {
+					s += fmt.Sprintf(" “%s…”", string([]rune(search)[:20]))
+				} else {
+					s += fmt.Sprintf(" “%s”", search)
+				}
+			}
+			s += fmt.Sprintf(" — s:filter /:search x:quit — (%d/%d) \n", len(log)-to, len(log))
+		default:
+			s = fmt.Sprintf(" u/d s:filter /:search </>:depth t:turns w:write x:quit — (%d/%d) \n", len(log)-to, len(log))
+		}
 		ui.DrawStyledTextLine(s, lines, FooterLine)
 		ui.Flush()
-		var quit bool
-		n, quit = ui.Scroll(n)
-		if quit {
-			break loop
+		in := ui.PollEvent()
+		switch in.key {
+		case "s", "S":
+			filter = (filter + 1) % logFilter(NumLogFilters)
+			log = g.FilteredLog(filter, search)
+			n = len(log) - lines
+		case "/":
+			text, err := ui.ReadText("Search", search)
+			if err == nil {
+				search = text
+				log = g.FilteredLog(filter, search)
+				n = len(log) - lines
+			}
+		case "<":
+			if n < len(log) {
+				if start := DepthStart(log, n); start < n {
+					n = start
+				} else if n > 0 {
+					n = DepthStart(log, n-1)
+				}
+			}
+		case ">":
+			if n < len(log) {
+				n = DepthNext(log, n)
+			}
+		case "t", "T":
+			stamps = !stamps
+		case "w", "W":
+			file, err := g.WriteLog()
+			if err != nil {
+				status = err.Error()
+			} else {
+				status = fmt.Sprintf("Log written to %s.", file)
+			}
+		default:
+			var quit bool
+			n, quit = ui.ScrollEvent(in, n)
+			if quit {
+				break loop
+			}
 		}
 	}
 }
@@ -1456,6 +1562,18 @@ func (ui *gameui) DrawMonsterDescription(mons *monster) {
 	s := mons.Kind.Desc()
 	s += " " + fmt.Sprintf("They can hit for up to %d damage.", mons.Kind.BaseAttack())
 	s += " " + fmt.Sprintf("They have around %d HP.", mons.Kind.MaxHP())
+	for _, cm := range mons.SortedMods() {
+		s += " " + fmt.Sprintf("This %s champion %s.", cm, cm.Desc())
+	}
+	for _, e := range mons.Effects.Sorted() {
+		s += " " + fmt.Sprintf("It %s.", e.Desc())
+	}
+	if mons.Kind.UsesItems() {
+		s += " They can pick up and use potions and magaras."
+	}
+	if len(mons.Items) > 0 || len(mons.Gear) > 0 || len(mons.Rods) > 0 {
+		s += " " + fmt.Sprintf("This one carries %s.", mons.ItemsText())
+	}
 	ui.DrawDescription(s)
 }
 
@@ -1669,12 +1787,12 @@ func (ui *gameui) RodItem(i, lnum int, r rod, fg uicolor) {
 	g := ui.g
 	bg := ui.ListItemBG(i)
 	ui.ClearLineWithColor(lnum, bg)
-	mc := r.MaxCharge()
-	if g.Player.Armour == CelmistRobe {
-		mc += 2
+	name := r.String()
+	if lvl := g.Player.Rods[r].Level(); lvl > 0 {
+		name = fmt.Sprintf("%s +%d", name, lvl)
 	}
 	ui.DrawColoredTextOnBG(fmt.Sprintf("%c - %s (%d/%d charges, %d mana cost)",
-		rune(i+97), r, g.Player.Rods[r].Charge, mc, r.MPCost()), 0, lnum, fg, bg)
+		rune(i+97), name, g.Player.Rods[r].Charge, g.RodMaxCharge(r), g.RodMPCost(r)), 0, lnum, fg, bg)
 }
 
 func (ui *gameui) SelectRod(ev event) error {
@@ -1719,6 +1837,71 @@ func (ui *gameui) SelectRod(ev event) error {
 	}
 }
 
+func (ui *gameui) SelectRodToEnhance() (rod, error) {
+	g := ui.g
+	rs := g.SortedRods()
+	ui.ClearLine(0)
+	ui.DrawColoredText("Enhance", 0, 0, ColorCyan)
+	col := utf8.RuneCountInString("Enhance")
+	ui.DrawText(" which rod?", col, 0)
+	for i, r := range rs {
+		ui.RodItem(i, i+1, r, ColorFg)
+	}
+	ui.DrawTextLine(" press (x) to cancel ", len(rs)+1)
+	ui.Flush()
+	for {
+		index, alt, err := ui.Select(len(rs))
+		if alt {
+			continue
+		}
+		if err != nil {
+			ui.DrawDungeonView(NoFlushMode)
+			return RodDigging, err
+		}
+		ui.RodItem(index, index+1, rs[index], ColorYellow)
+		ui.Flush()
+		time.Sleep(75 * time.Millisecond)
+		return rs[index], nil
+	}
+}
+
+func (ui *gameui) RodUpgradeItem(i, lnum int, r rod, u rodUpgrade, fg uicolor) {
+	g := ui.g
+	bg := ui.ListItemBG(i)
+	ui.ClearLineWithColor(lnum, bg)
+	if g.CanUpgradeRod(r, u) != nil && fg == ColorFg {
+		fg = ColorFgDark
+	}
+	ui.DrawColoredTextOnBG(fmt.Sprintf("%c - %s", rune(i+97), u), 0, lnum, fg, bg)
+}
+
+func (ui *gameui) SelectRodUpgrade(r rod) (rodUpgrade, error) {
+	ui.ClearLine(0)
+	ui.DrawColoredText("Enhance", 0, 0, ColorCyan)
+	col := utf8.RuneCountInString("Enhance")
+	ui.DrawText(fmt.Sprintf(" your %s how?", r), col, 0)
+	for i, u := range RodUpgrades {
+		ui.RodUpgradeItem(i, i+1, r, u, ColorFg)
+	}
+	ui.DrawTextLine(" press (x) to cancel ", len(RodUpgrades)+1)
+	ui.Flush()
+	for {
+		index, alt, err := ui.Select(len(RodUpgrades))
+		if alt {
+			continue
+		}
+		if err != nil {
+			ui.DrawDungeonView(NoFlushMode)
+			return RodUpgradeCharges, err
+		}
+		ui.RodUpgradeItem(index, index+1, r, RodUpgrades[index], ColorYellow)
+		ui.Flush()
+		time.Sleep(75 * time.Millisecond)
+		ui.DrawDungeonView(NoFlushMode)
+		return RodUpgrades[index], nil
+	}
+}
+
 func (ui *gameui) AbbreviatedItem(lnum, col int, name string, q int) {
 	bg := ui.ListItemBG(lnum)
 	if col == 0 {
@@ -1837,8 +2020,20 @@ const (
 	invertLOS
 	toggleLayout
 	toggleTiles
+	toggleExploreItems
+	toggleExploreHazards
+	toggleExploreSleepers
+	toggleExploreStairs
+	cycleAutoFightHP
 )
 
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
 func (s setting) String() (text string) {
 	switch s {
 	case setKeys:
@@ -1849,6 +2044,16 @@ func (s setting) String() (text string) {
 		text = "Toggle normal/compact layout"
 	case toggleTiles:
 		text = "Toggle Tiles/Ascii display"
+	case toggleExploreItems:
+		text = fmt.Sprintf("Toggle autoexplore visiting items (%s)", onOff(!GameConfig.ExploreSkipItems))
+	case toggleExploreHazards:
+		text = fmt.Sprintf("Toggle autoexplore avoiding hazards (%s)", onOff(!GameConfig.ExploreThroughHazards))
+	case toggleExploreSleepers:
+		text = fmt.Sprintf("Toggle autoexplore ignoring far sleepers (%s)", onOff(!GameConfig.ExploreWaitSleepers))
+	case toggleExploreStairs:
+		text = fmt.Sprintf("Toggle autoexplore stairs travel offer (%s)", onOff(!GameConfig.ExploreNoStairs))
+	case cycleAutoFightHP:
+		text = fmt.Sprintf("Cycle auto-fight minimal HP (%d%%)", AutoFightHP())
 	}
 	return text
 }
@@ -1857,6 +2062,11 @@ var settingsActions = []setting{
 	setKeys,
 	invertLOS,
 	toggleLayout,
+	toggleExploreItems,
+	toggleExploreHazards,
+	toggleExploreSleepers,
+	toggleExploreStairs,
+	cycleAutoFightHP,
 }
 
 func (ui *gameui) ConfItem(i, lnum int, s setting, fg uicolor) {
@@ -1924,10 +2134,118 @@ func (ui *gameui) HandleSettingAction() error {
 		if err != nil {
 			g.Print(err.Error())
 		}
+	case toggleExploreItems, toggleExploreHazards, toggleExploreSleepers, toggleExploreStairs:
+		switch s {
+		case toggleExploreItems:
+			GameConfig.ExploreSkipItems = !GameConfig.ExploreSkipItems
+		case toggleExploreHazards:
+			GameConfig.ExploreThroughHazards = !GameConfig.ExploreThroughHazards
+		case toggleExploreSleepers:
+			GameConfig.ExploreWaitSleepers = !GameConfig.ExploreWaitSleepers
+		case toggleExploreStairs:
+			GameConfig.ExploreNoStairs = !GameConfig.ExploreNoStairs
+		}
+		g.DijkstraMapRebuild = true
+		err := g.SaveConfig()
+		if err != nil {
+			g.Print(err.Error())
+		}
+	case cycleAutoFightHP:
+		GameConfig.AutoFightHP = NextAutoFightHP()
+		err := g.SaveConfig()
+		if err != nil {
+			g.Print(err.Error())
+		}
 	}
 	return nil
 }
 
+func (ui *gameui) BackgroundItem(i, lnum int, bg background, fg uicolor) {
+	lbg := ui.ListItemBG(i)
+	ui.ClearLineWithColor(lnum, lbg)
+	ui.DrawColoredTextOnBG(fmt.Sprintf("%c - %s", rune(i+97), bg), 0, lnum, fg, lbg)
+}
+
+func (ui *gameui) SelectBackground() background {
+	ui.Clear()
+	for {
+		ui.ClearLine(0)
+		ui.DrawColoredText("Choose", 0, 0, ColorCyan)
+		col := utf8.RuneCountInString("Choose")
+		ui.DrawText(" your background:", col, 0)
+		for i, bg := range Backgrounds {
+			ui.BackgroundItem(i, i+1, bg, ColorFg)
+		}
+		ui.DrawTextLine(" press (x) for a wanderer ", len(Backgrounds)+1)
+		lnum := len(Backgrounds) + 3
+		for _, bg := range Backgrounds {
+			desc := formatText(fmt.Sprintf("%s: %s", strings.Title(bg.String()), bg.Desc()), TextWidth)
+			ui.DrawText(desc, 0, lnum)
+			lnum += strings.Count(desc, "\n") + 2
+		}
+		ui.Flush()
+		index, alt, err := ui.Select(len(Backgrounds))
+		if alt {
+			continue
+		}
+		if err != nil {
+			return BgWanderer
+		}
+		ui.BackgroundItem(index, index+1, Backgrounds[index], ColorYellow)
+		ui.Flush()
+		time.Sleep(75 * time.Millisecond)
+		return Backgrounds[index]
+	}
+}
+
+func (ui *gameui) DifficultyItem(i, lnum int, d difficulty, fg uicolor) {
+	bg := ui.ListItemBG(i)
+	ui.ClearLineWithColor(lnum, bg)
+	ui.DrawColoredTextOnBG(fmt.Sprintf("%c - %s", rune(i+97), d), 0, lnum, fg, bg)
+}
+
+func (ui *gameui) SelectDifficulty() difficulty {
+	ui.Clear()
+	for {
+		ui.ClearLine(0)
+		ui.DrawColoredText("Choose", 0, 0, ColorCyan)
+		col := utf8.RuneCountInString("Choose")
+		ui.DrawText(" the difficulty:", col, 0)
+		for i, d := range Difficulties {
+			ui.DifficultyItem(i, i+1, d, ColorFg)
+		}
+		ui.DrawTextLine(" press (x) for normal ", len(Difficulties)+1)
+		lnum := len(Difficulties) + 3
+		for _, d := range Difficulties {
+			desc := formatText(fmt.Sprintf("%s: %s", strings.Title(d.String()), d.Desc()), TextWidth)
+			ui.DrawText(desc, 0, lnum)
+			lnum += strings.Count(desc, "\n") + 2
+		}
+		ui.Flush()
+		index, alt, err := ui.Select(len(Difficulties))
+		if alt {
+			continue
+		}
+		if err != nil {
+			return NormalDifficulty
+		}
+		ui.DifficultyItem(index, index+1, Difficulties[index], ColorYellow)
+		ui.Flush()
+		time.Sleep(75 * time.Millisecond)
+		return Difficulties[index]
+	}
+}
+
+func (ui *gameui) DifficultyColor() uicolor {
+	switch ui.g.Opts.Difficulty {
+	case EasyDifficulty:
+		return ColorFgStatusGood
+	case HardDifficulty:
+		return ColorFgStatusBad
+	}
+	return ColorFg
+}
+
 func (ui *gameui) WizardItem(i, lnum int, s wizardAction, fg uicolor) {
 	bg := ui.ListItemBG(i)
 	ui.ClearLineWithColor(lnum, bg)
@@ -21,12 +21,6 @@ func (cs consumableSlice) Len() int           { return len(cs) }
 func (cs consumableSlice) Swap(i, j int)      { cs[i], cs[j] = cs[j], cs[i] }
 func (cs consumableSlice) Less(i, j int) bool { return cs[i].Int() < cs[j].Int() }
 
-type statusSlice []status
-
-func (sts statusSlice) Len() int           { return len(sts) }
-func (sts statusSlice) Swap(i, j int)      { sts[i], sts[j] = sts[j], sts[i] }
-func (sts statusSlice) Less(i, j int) bool { return sts[i] < sts[j] }
-
 type monsSlice []monsterKind
 
 func (ms monsSlice) Len() int      { return len(ms) }
@@ -51,10 +45,8 @@ func (g *game) DumpAptitudes() string {
 
 func (g *game) DumpStatuses() string {
 	sts := sort.StringSlice{}
-	for st, c := range g.Player.Statuses {
-		if c > 0 {
-			sts = append(sts, st.String())
-		}
+	for _, e := range g.Player.Effects.Sorted() {
+		sts = append(sts, g.Player.Effects.Text(e))
 	}
 	sort.Sort(sts)
 	if len(sts) == 0 {
@@ -111,6 +103,31 @@ func (g *game) SortedProjectiles() consumableSlice {
 func (g *game) Dump() string {
 	buf := &bytes.Buffer{}
 	fmt.Fprintf(buf, " -- Boohu version %s character file --\n\n", Version)
+	g.DumpCharacter(buf)
+	fmt.Fprintf(buf, "Last messages:\n")
+	for i := len(g.Log) - 10; i < len(g.Log); i++ {
+		if i >= 0 {
+			fmt.Fprintf(buf, "%s\n", g.Log[i])
+		}
+	}
+	fmt.Fprintf(buf, "\n")
+	fmt.Fprintf(buf, "Dungeon:\n")
+	fmt.Fprintf(buf, "┌%s┐\n", strings.Repeat("─", DungeonWidth))
+	buf.WriteString(g.DumpDungeon())
+	fmt.Fprintf(buf, "└%s┘\n", strings.Repeat("─", DungeonWidth))
+	fmt.Fprintf(buf, "\n")
+	buf.WriteString(g.DumpAnnotations())
+	fmt.Fprintf(buf, g.DumpedKilledMonsters())
+	fmt.Fprintf(buf, "\n")
+	fmt.Fprintf(buf, "Timeline:\n")
+	fmt.Fprintf(buf, g.DumpStory())
+	fmt.Fprintf(buf, "\n")
+	g.DetailedStatistics(buf)
+	return buf.String()
+}
+
+// DumpCharacter writes the state of the character and its equipment.
+func (g *game) DumpCharacter(buf io.Writer) {
 	if g.Wizard {
 		fmt.Fprintf(buf, "**WIZARD MODE**\n")
 	}
@@ -121,6 +138,8 @@ func (g *game) Dump() string {
 	} else {
 		fmt.Fprintf(buf, "You are exploring depth %d of Hareka's Underground.\n", g.Depth)
 	}
+	fmt.Fprintf(buf, "You started as %s (%s difficulty).\n", Indefinite(g.Player.Background.String(), false), g.Opts.Difficulty)
+	io.WriteString(buf, g.DumpGhost())
 	fmt.Fprintf(buf, "\n")
 	fmt.Fprintf(buf, "You have %d/%d HP, and %d/%d MP.\n", g.Player.HP, g.Player.HPMax(), g.Player.MP, g.Player.MPMax())
 	fmt.Fprintf(buf, "\n")
@@ -143,12 +162,13 @@ func (g *game) Dump() string {
 	if len(rs) > 0 {
 		fmt.Fprintf(buf, "Rods:\n")
 		for _, r := range rs {
-			mc := r.MaxCharge()
-			if g.Player.Armour == CelmistRobe {
-				mc += 2
+			props := g.Player.Rods[r]
+			fmt.Fprintf(buf, "- %s", r)
+			if props.Level() > 0 {
+				fmt.Fprintf(buf, " +%d", props.Level())
 			}
-			fmt.Fprintf(buf, "- %s (%d/%d charges) (used %d times)\n",
-				r, g.Player.Rods[r].Charge, mc, g.Stats.UsedRod[r])
+			fmt.Fprintf(buf, " (%d/%d charges, %d mana cost) (used %d times)\n",
+				props.Charge, g.RodMaxCharge(r), g.RodMPCost(r), g.Stats.UsedRod[r])
 		}
 	} else {
 		fmt.Fprintf(buf, "You do not have any rods.\n")
@@ -185,25 +205,6 @@ func (g *game) Dump() string {
 	}
 	fmt.Fprintf(buf, "You explored %d level%s out of %d.\n", maxDepth, s, MaxDepth)
 	fmt.Fprintf(buf, "\n")
-	fmt.Fprintf(buf, "Last messages:\n")
-	for i := len(g.Log) - 10; i < len(g.Log); i++ {
-		if i >= 0 {
-			fmt.Fprintf(buf, "%s\n", g.Log[i])
-		}
-	}
-	fmt.Fprintf(buf, "\n")
-	fmt.Fprintf(buf, "Dungeon:\n")
-	fmt.Fprintf(buf, "┌%s┐\n", strings.Repeat("─", DungeonWidth))
-	buf.WriteString(g.DumpDungeon())
-	fmt.Fprintf(buf, "└%s┘\n", strings.Repeat("─", DungeonWidth))
-	fmt.Fprintf(buf, "\n")
-	fmt.Fprintf(buf, g.DumpedKilledMonsters())
-	fmt.Fprintf(buf, "\n")
-	fmt.Fprintf(buf, "Timeline:\n")
-	fmt.Fprintf(buf, g.DumpStory())
-	fmt.Fprintf(buf, "\n")
-	g.DetailedStatistics(buf)
-	return buf.String()
 }
 
 func (g *game) DetailedStatistics(w io.Writer) {
@@ -218,6 +219,7 @@ func (g *game) DetailedStatistics(w io.Writer) {
 	fmt.Fprintf(w, "You endured %d damage.\n", g.Stats.Damage)
 	fmt.Fprintf(w, "You were lucky %d times.\n", g.Stats.TimesLucky)
 	fmt.Fprintf(w, "You activated %d stones.\n", g.Stats.UsedStones)
+	fmt.Fprintf(w, "You enhanced your rods %d times.\n", g.Stats.RodUpgrades)
 	fmt.Fprintf(w, "There were %d fires.\n", g.Stats.Burns)
 	fmt.Fprintf(w, "There were %d destroyed walls.\n", g.Stats.Digs)
 	fmt.Fprintf(w, "You rested %d times (%d interruptions).\n", g.Stats.Rest, g.Stats.RestInterrupt)
@@ -346,11 +348,13 @@ func (g *game) DumpDungeon() string {
 					r = '_'
 				} else if _, ok := g.Simellas[pos]; ok {
 					r = '♣'
-				} else if _, ok := g.Doors[pos]; ok {
-					r = '+'
+				} else if d, ok := g.Doors[pos]; ok {
+					r = d.Letter()
+				} else if _, ok := g.Keys[pos]; ok {
+					r = '-'
 				}
 				m := g.MonsterAt(pos)
-				if m.Exists() && (g.Player.LOS[m.Pos] || g.Wizard) {
+				if m.Exists() && (m.Visible(g) || g.Wizard) {
 					r = m.Kind.Letter()
 				}
 			}
@@ -368,7 +372,11 @@ func (g *game) DumpedKilledMonsters() string {
 	fmt.Fprint(buf, "Killed Monsters:\n")
 	ms := g.SortedKilledMonsters()
 	for _, mk := range ms {
-		fmt.Fprintf(buf, "- %s: %d\n", mk, g.Stats.KilledMons[mk])
+		if n := g.Stats.KilledChampions[mk]; n > 0 {
+			fmt.Fprintf(buf, "- %s: %d (%d champions)\n", mk, g.Stats.KilledMons[mk], n)
+		} else {
+			fmt.Fprintf(buf, "- %s: %d\n", mk, g.Stats.KilledMons[mk])
+		}
 	}
 	return buf.String()
 }
@@ -386,6 +394,7 @@ func (g *game) SimplifedDump(err error) string {
 	} else {
 		fmt.Fprintf(buf, "You are exploring depth %d of Hareka's Underground.\n", g.Depth)
 	}
+	fmt.Fprintf(buf, "You started as %s (%s difficulty).\n", Indefinite(g.Player.Background.String(), false), g.Opts.Difficulty)
 	fmt.Fprintf(buf, "You collected %d simellas.\n", g.Player.Simellas)
 	fmt.Fprintf(buf, "You killed %d monsters.\n", g.Stats.Killed)
 	fmt.Fprintf(buf, "You spent %.0f turns in the Underground.\n", float64(g.Turn)/10)
@@ -396,6 +405,10 @@ func (g *game) SimplifedDump(err error) string {
 	}
 	fmt.Fprintf(buf, "You explored %d level%s out of %d.\n", maxDepth, s, MaxDepth+1)
 	fmt.Fprintf(buf, "\n")
+	if score := g.DumpScore(); score != "" {
+		fmt.Fprint(buf, score)
+		fmt.Fprintf(buf, "\n")
+	}
 	if err != nil {
 		fmt.Fprintf(buf, "Error writing dump: %v.\n", err)
 	} else {
@@ -405,6 +418,9 @@ func (g *game) SimplifedDump(err error) string {
 				fmt.Fprintf(buf, "Full game statistics written below.\n")
 			} else {
 				fmt.Fprintf(buf, "Full game statistics dump written to %s.\n", filepath.Join(dataDir, "dump"))
+				if HTMLDump {
+					fmt.Fprintf(buf, "HTML version written to %s.\n", filepath.Join(dataDir, "dump.html"))
+				}
 			}
 		}
 	}
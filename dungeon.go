@@ -699,7 +699,7 @@ func (g *game) GenRoomMap(h, w int) {
 func (g *game) PutDoorsList(doors map[position]bool, threshold int) {
 	for pos := range doors {
 		if g.DoorCandidate(pos) && RandInt(100) > threshold {
-			g.Doors[pos] = true
+			g.Doors[pos] = RandomDoorState()
 			if _, ok := g.Fungus[pos]; ok {
 				delete(g.Fungus, pos)
 			}
@@ -834,7 +834,7 @@ loop:
 	g.PutDoors(5)
 	for pos := range doors {
 		if g.DoorCandidate(pos) && RandInt(100) > 20 {
-			g.Doors[pos] = true
+			g.Doors[pos] = RandomDoorState()
 			if _, ok := g.Fungus[pos]; ok {
 				delete(g.Fungus, pos)
 			}
@@ -1114,7 +1114,7 @@ loop:
 	g.PutDoors(10)
 	for pos := range doors {
 		if g.DoorCandidate(pos) && RandInt(100) > 20 {
-			g.Doors[pos] = true
+			g.Doors[pos] = RandomDoorState()
 			if _, ok := g.Fungus[pos]; ok {
 				delete(g.Fungus, pos)
 			}
@@ -1237,14 +1237,14 @@ func (g *game) ExtendEdgeRoom(r room, doors map[position]bool) room {
 		if pos.X == 0 || pos.X == DungeonWidth-1 || pos.Y == 0 || pos.Y == DungeonHeight-1 {
 			continue
 		}
-		if g.Doors[pos] {
+		if _, ok := g.Doors[pos]; ok {
 			ndoors++
 		}
 		ndoorsc = append(ndoorsc, pos)
 	}
 	for i := 0; i < 1+RandInt(2-ndoors); i++ {
 		dpos := ndoorsc[RandInt(len(ndoorsc))]
-		g.Doors[dpos] = true
+		g.Doors[dpos] = RandomDoorState()
 		g.Dungeon.SetCell(dpos, FreeCell)
 	}
 	return r
@@ -1287,7 +1287,7 @@ loop:
 		g.Dungeon.SetCell(position{r.pos.X + dx, i}, WallCell)
 	}
 	doorpos := position{r.pos.X + dx, r.pos.Y + r.h/2}
-	g.Doors[doorpos] = true
+	g.Doors[doorpos] = RandomDoorState()
 	g.Dungeon.SetCell(doorpos, FreeCell)
 }
 
@@ -1328,7 +1328,7 @@ loop:
 		g.Dungeon.SetCell(position{i, r.pos.Y + dy}, WallCell)
 	}
 	doorpos := position{r.pos.X + r.w/2, r.pos.Y + dy}
-	g.Doors[doorpos] = true
+	g.Doors[doorpos] = RandomDoorState()
 	g.Dungeon.SetCell(doorpos, FreeCell)
 }
 
@@ -1413,7 +1413,7 @@ func (g *game) GenBSPMap(height, width int) {
 		d.SetCell(idxtopos(i), FreeCell)
 	}
 	g.Dungeon = d
-	g.Doors = map[position]bool{}
+	g.Doors = map[position]door{}
 	special := 0
 	empty := 0
 	for i, r := range rooms {
@@ -1447,7 +1447,7 @@ func (g *game) GenBSPMap(height, width int) {
 		}
 		for pos := range doors {
 			if g.DoorCandidate(pos) && RandInt(100) > 10 {
-				g.Doors[pos] = true
+				g.Doors[pos] = RandomDoorState()
 			}
 		}
 		if RandInt(2) == 0 {
@@ -1539,7 +1539,7 @@ func (g *game) DoorCandidate(pos position) bool {
 	}
 	return pos.W().valid() && pos.E().valid() &&
 		d.Cell(pos.W()).T == FreeCell && d.Cell(pos.E()).T == FreeCell &&
-		!g.Doors[pos.W()] && !g.Doors[pos.E()] &&
+		!g.IsDoor(pos.W()) && !g.IsDoor(pos.E()) &&
 		(!pos.N().valid() || d.Cell(pos.N()).T == WallCell) &&
 		(!pos.S().valid() || d.Cell(pos.S()).T == WallCell) &&
 		((pos.NW().valid() && d.Cell(pos.NW()).T == FreeCell) ||
@@ -1548,7 +1548,7 @@ func (g *game) DoorCandidate(pos position) bool {
 			(pos.SE().valid() && d.Cell(pos.SE()).T == FreeCell)) ||
 		pos.N().valid() && pos.S().valid() &&
 			d.Cell(pos.N()).T == FreeCell && d.Cell(pos.S()).T == FreeCell &&
-			!g.Doors[pos.N()] && !g.Doors[pos.S()] &&
+			!g.IsDoor(pos.N()) && !g.IsDoor(pos.S()) &&
 			(!pos.E().valid() || d.Cell(pos.E()).T == WallCell) &&
 			(!pos.W().valid() || d.Cell(pos.W()).T == WallCell) &&
 			((pos.NW().valid() && d.Cell(pos.NW()).T == FreeCell) ||
@@ -1558,11 +1558,11 @@ func (g *game) DoorCandidate(pos position) bool {
 }
 
 func (g *game) PutDoors(percentage int) {
-	g.Doors = map[position]bool{}
+	g.Doors = map[position]door{}
 	for i := range g.Dungeon.Cells {
 		pos := idxtopos(i)
 		if g.DoorCandidate(pos) && RandInt(100) < percentage {
-			g.Doors[pos] = true
+			g.Doors[pos] = RandomDoorState()
 			if _, ok := g.Fungus[pos]; ok {
 				delete(g.Fungus, pos)
 			}
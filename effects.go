@@ -0,0 +1,604 @@
+package main
+
+import "fmt"
+
+// effects are timed statuses shared by the player and monsters. Each effect
+// declares its duration, stacking, tick period and texts in EffectsData, and
+// its behaviour in the tick and end methods below, so that new effects do
+// not require new event actions.
+
+type effect int
+
+const (
+	EffBerserk effect = iota
+	EffSlow
+	EffExhausted
+	EffSwift
+	EffAgile
+	EffLignification
+	EffConfusion
+	EffTele
+	EffNausea
+	EffDisabledShield
+	EffCorrosion
+	EffFlames // fake effect, only displayed
+	EffDig
+	EffSwap
+	EffShadows
+	EffSlay
+	EffAccurate
+	EffPoison
+	EffFear
+	EffRegeneration
+	EffInvisibility
+)
+
+const NumEffects = int(EffInvisibility) + 1
+
+type effectStacking int
+
+const (
+	StackRefresh   effectStacking = iota // renew duration
+	StackIntensity                       // renew duration and increase intensity
+	StackExtend                          // add duration
+	StackCount                           // each application lasts its own duration
+)
+
+type effectData struct {
+	name         string
+	short        string
+	good         bool
+	bad          bool
+	duration     int
+	durationRand int
+	stacking     effectStacking
+	maxIntensity int
+	tick         int
+	desc         string
+}
+
+var EffectsData = [NumEffects]effectData{
+	EffBerserk: {
+		name:         "Berserk",
+		short:        "Be",
+		good:         true,
+		duration:     65,
+		durationRand: 20,
+		desc:         "attacks and moves faster",
+	},
+	EffSlow: {
+		name:         "Slow",
+		short:        "Sl",
+		bad:          true,
+		duration:     30,
+		durationRand: 10,
+		stacking:     StackCount,
+		desc:         "moves and attacks slower",
+	},
+	EffExhausted: {
+		name:         "Exhausted",
+		short:        "Ex",
+		duration:     100,
+		durationRand: 50,
+		stacking:     StackCount,
+		desc:         "needs some rest",
+	},
+	EffSwift: {
+		name:         "Swift",
+		short:        "Sw",
+		good:         true,
+		duration:     40,
+		durationRand: 20,
+		stacking:     StackCount,
+		desc:         "moves faster",
+	},
+	EffAgile: {
+		name:         "Agile",
+		short:        "Ag",
+		good:         true,
+		duration:     85,
+		durationRand: 20,
+		stacking:     StackCount,
+		desc:         "dodges better",
+	},
+	EffLignification: {
+		name:         "Lignified",
+		short:        "Li",
+		duration:     150,
+		durationRand: 100,
+		stacking:     StackCount,
+		desc:         "is rooted to the ground",
+	},
+	EffConfusion: {
+		name:         "Confused",
+		short:        "Co",
+		bad:          true,
+		duration:     100,
+		durationRand: 100,
+		desc:         "moves erratically",
+	},
+	EffTele: {
+		name:         "Tele",
+		short:        "Te",
+		duration:     20,
+		durationRand: 30,
+		desc:         "is about to teleport",
+	},
+	EffNausea: {
+		name:         "Nausea",
+		short:        "Na",
+		bad:          true,
+		duration:     30,
+		durationRand: 20,
+		desc:         "cannot drink potions",
+	},
+	EffDisabledShield: {
+		name:         "-Shield",
+		short:        "-S",
+		bad:          true,
+		duration:     100,
+		durationRand: 100,
+		desc:         "blocks less",
+	},
+	EffCorrosion: {
+		name:         "Corroded",
+		short:        "Co",
+		bad:          true,
+		duration:     80,
+		durationRand: 40,
+		stacking:     StackCount,
+		desc:         "has corroded equipment",
+	},
+	EffFlames: {
+		name:  "Flames",
+		short: "Fl",
+		bad:   true,
+		desc:  "is burning",
+	},
+	EffDig: {
+		name:         "Dig",
+		short:        "Di",
+		good:         true,
+		duration:     75,
+		durationRand: 20,
+		desc:         "digs through walls",
+	},
+	EffSwap: {
+		name:         "Swap",
+		short:        "Sw",
+		good:         true,
+		duration:     130,
+		durationRand: 41,
+		desc:         "swaps with monsters",
+	},
+	EffShadows: {
+		name:         "Shadows",
+		short:        "Sh",
+		good:         true,
+		duration:     130,
+		durationRand: 41,
+		desc:         "is surrounded by shadows",
+	},
+	EffSlay: {
+		name:     "Slay",
+		short:    "Sl",
+		good:     true,
+		duration: 60,
+		stacking: StackCount,
+		desc:     "hits harder",
+	},
+	EffAccurate: {
+		name:         "Accurate",
+		short:        "Ac",
+		good:         true,
+		duration:     85,
+		durationRand: 20,
+		stacking:     StackCount,
+		desc:         "hits more often",
+	},
+	EffPoison: {
+		name:         "Poisoned",
+		short:        "Po",
+		bad:          true,
+		duration:     50,
+		durationRand: 30,
+		stacking:     StackIntensity,
+		maxIntensity: 3,
+		tick:         10,
+		desc:         "loses health each turn",
+	},
+	EffFear: {
+		name:         "Afraid",
+		short:        "Fe",
+		bad:          true,
+		duration:     40,
+		durationRand: 20,
+		desc:         "cannot attack in melee",
+	},
+	EffRegeneration: {
+		name:         "Regenerating",
+		short:        "Re",
+		good:         true,
+		duration:     60,
+		durationRand: 20,
+		tick:         20,
+		desc:         "recovers health over time",
+	},
+	EffInvisibility: {
+		name:         "Invisible",
+		short:        "In",
+		good:         true,
+		duration:     60,
+		durationRand: 40,
+		desc:         "cannot be seen",
+	},
+}
+
+func (e effect) String() string {
+	return EffectsData[e].name
+}
+
+func (e effect) Short() string {
+	return EffectsData[e].short
+}
+
+func (e effect) Good() bool {
+	return EffectsData[e].good
+}
+
+func (e effect) Bad() bool {
+	return EffectsData[e].bad
+}
+
+func (e effect) Desc() string {
+	return EffectsData[e].desc
+}
+
+func (e effect) Duration() int {
+	d := EffectsData[e].duration
+	if EffectsData[e].durationRand > 0 {
+		d += RandInt(EffectsData[e].durationRand)
+	}
+	return d
+}
+
+type effectState struct {
+	Intensity int
+	Expire    int
+	NextTick  int
+}
+
+type effects map[effect]effectState
+
+func (effs effects) Has(e effect) bool {
+	return effs[e].Intensity > 0
+}
+
+func (effs effects) Intensity(e effect) int {
+	return effs[e].Intensity
+}
+
+// apply adds the effect e with duration d at rank turn, following its
+// stacking rules. It returns the new state and whether the effect was
+// already active.
+func (effs effects) apply(e effect, turn, d int) (effectState, bool) {
+	st, active := effs[e]
+	active = active && st.Intensity > 0
+	data := EffectsData[e]
+	if !active {
+		st = effectState{Intensity: 1, Expire: turn + d}
+		if data.tick > 0 {
+			st.NextTick = turn + data.tick
+		}
+		effs[e] = st
+		return st, false
+	}
+	switch data.stacking {
+	case StackIntensity:
+		if st.Intensity < data.maxIntensity {
+			st.Intensity++
+		}
+		if turn+d > st.Expire {
+			st.Expire = turn + d
+		}
+	case StackExtend:
+		st.Expire += d
+	case StackCount:
+		st.Intensity++
+		if turn+d > st.Expire {
+			st.Expire = turn + d
+		}
+	default:
+		if turn+d > st.Expire {
+			st.Expire = turn + d
+		}
+	}
+	effs[e] = st
+	return st, true
+}
+
+const PlayerEffects = -1
+
+type effectAction int
+
+const (
+	EffectTick effectAction = iota
+	EffectEnd
+)
+
+type effectEvent struct {
+	ERank   int
+	Eff     effect
+	NMons   int
+	EAction effectAction
+}
+
+func (eev *effectEvent) Rank() int {
+	return eev.ERank
+}
+
+func (eev *effectEvent) Renew(g *game, delay int) {
+	eev.ERank += delay
+	if delay == 0 {
+		g.PushAgainEvent(eev)
+	} else {
+		g.PushEvent(eev)
+	}
+}
+
+func (eev *effectEvent) Action(g *game) {
+	var effs effects
+	var mons *monster
+	if eev.NMons == PlayerEffects {
+		effs = g.Player.Effects
+	} else {
+		mons = g.Monsters[eev.NMons]
+		if !mons.Exists() {
+			return
+		}
+		effs = mons.Effects
+	}
+	st, ok := effs[eev.Eff]
+	if !ok || st.Intensity <= 0 {
+		return
+	}
+	switch eev.EAction {
+	case EffectTick:
+		if st.NextTick != eev.Rank() || st.Expire <= eev.Rank() {
+			// stale tick chain
+			return
+		}
+		st.NextTick += EffectsData[eev.Eff].tick
+		effs[eev.Eff] = st
+		if mons == nil {
+			g.PlayerEffectTick(eev.Eff, st, eev)
+		} else {
+			g.MonsterEffectTick(mons, eev.Eff, st, eev)
+		}
+		eev.Renew(g, EffectsData[eev.Eff].tick)
+	case EffectEnd:
+		if EffectsData[eev.Eff].stacking == StackCount {
+			// each application has its own end event
+			st.Intensity--
+			if st.Intensity > 0 {
+				effs[eev.Eff] = st
+				return
+			}
+		} else if st.Expire > eev.Rank() {
+			// the effect was renewed
+			return
+		}
+		delete(effs, eev.Eff)
+		if mons == nil {
+			g.PlayerEffectEnd(eev.Eff, eev)
+		} else {
+			g.MonsterEffectEnd(mons, eev.Eff)
+		}
+	}
+}
+
+func (g *game) pushEffectEvents(e effect, nmons int, st effectState, end int, renewed bool) {
+	if EffectsData[e].stacking != StackCount {
+		end = st.Expire
+	}
+	g.PushEvent(&effectEvent{ERank: end, Eff: e, NMons: nmons, EAction: EffectEnd})
+	if !renewed && EffectsData[e].tick > 0 {
+		g.PushEvent(&effectEvent{ERank: st.NextTick, Eff: e, NMons: nmons, EAction: EffectTick})
+	}
+}
+
+func (g *game) PlayerAddEffect(e effect, d int) {
+	if g.Player.Effects == nil {
+		g.Player.Effects = effects{}
+	}
+	st, renewed := g.Player.Effects.apply(e, g.Ev.Rank(), d)
+	g.pushEffectEvents(e, PlayerEffects, st, g.Ev.Rank()+d, renewed)
+	if !renewed {
+		switch e {
+		case EffPoison:
+			g.PrintStyled("You are poisoned.", logMonsterHit)
+		case EffFear:
+			g.PrintStyled("You feel afraid.", logMonsterHit)
+		case EffRegeneration:
+			g.Print("You feel your wounds closing.")
+		case EffInvisibility:
+			g.Print("You become translucent.")
+		}
+	}
+}
+
+func (m *monster) AddEffect(g *game, e effect, d int) {
+	if m.Effects == nil {
+		m.Effects = effects{}
+	}
+	st, renewed := m.Effects.apply(e, g.Ev.Rank(), d)
+	g.pushEffectEvents(e, m.Index, st, g.Ev.Rank()+d, renewed)
+	if !renewed && (m.Visible(g) || e == EffInvisibility && g.Player.LOS[m.Pos] && !m.Kind.Invisible()) {
+		switch e {
+		case EffPoison:
+			g.Printf("%s is poisoned.", m.Kind.Definite(true))
+		case EffFear:
+			g.Printf("%s looks afraid.", m.Kind.Definite(true))
+		case EffRegeneration:
+			g.Printf("%s starts regenerating.", m.Kind.Definite(true))
+		case EffInvisibility:
+			g.Printf("%s fades from view.", m.Kind.Definite(true))
+		case EffSwift:
+			g.Printf("%s moves faster.", m.Kind.Definite(true))
+		}
+	}
+	if e == EffFear {
+		m.Path = nil
+	}
+}
+
+func (p *player) HasEffect(e effect) bool {
+	return p.Effects.Has(e)
+}
+
+func (m *monster) HasEffect(e effect) bool {
+	return m.Effects.Has(e)
+}
+
+func (g *game) PlayerEffectTick(e effect, st effectState, ev event) {
+	switch e {
+	case EffPoison:
+		damage := st.Intensity
+		g.PrintfStyled("The poison hurts you (%d dmg).", logMonsterHit, damage)
+		g.DamagePlayer(damage)
+		g.StopAuto()
+	case EffRegeneration:
+		if g.Player.HP < g.Player.HPMax() {
+			g.Player.HP++
+		}
+	}
+}
+
+func (g *game) MonsterEffectTick(m *monster, e effect, st effectState, ev event) {
+	switch e {
+	case EffPoison:
+		m.HP -= st.Intensity
+		if m.HP <= 0 {
+			if m.Visible(g) {
+				g.PrintfStyled("%s is killed by the poison.", logPlayerHit, m.Kind.Definite(true))
+			}
+			g.HandleKill(m, ev)
+		}
+	case EffRegeneration:
+		if m.HP < m.HPmax {
+			m.HP++
+		}
+	}
+}
+
+func (g *game) PlayerEffectEnd(e effect, ev event) {
+	switch e {
+	case EffBerserk:
+		g.Player.HP -= int(10 * g.Player.HP / Max(g.Player.HPMax(), g.Player.HP))
+		g.PrintStyled("You are no longer berserk.", logStatusEnd)
+		g.PlayerAddEffect(EffSlow, 90+RandInt(30))
+		g.PlayerAddEffect(EffExhausted, 270+RandInt(60))
+	case EffSlow:
+		g.PrintStyled("You no longer feel slow.", logStatusEnd)
+	case EffExhausted:
+		g.PrintStyled("You no longer feel exhausted.", logStatusEnd)
+	case EffSwift:
+		g.PrintStyled("You no longer feel speedy.", logStatusEnd)
+	case EffAgile:
+		g.PrintStyled("You no longer feel agile.", logStatusEnd)
+	case EffLignification:
+		g.Player.HP -= int(10 * g.Player.HP / Max(g.Player.HPMax(), g.Player.HP))
+		g.PrintStyled("You no longer feel attached to the ground.", logStatusEnd)
+	case EffConfusion:
+		g.PrintStyled("You no longer feel confused.", logStatusEnd)
+	case EffTele:
+		if !g.Player.HasEffect(EffLignification) {
+			g.Teleportation(ev)
+		} else {
+			g.Print("Lignification has prevented teleportation.")
+		}
+		return
+	case EffNausea:
+		g.PrintStyled("You no longer feel sick.", logStatusEnd)
+	case EffDisabledShield:
+		g.PrintStyled("You manage to dislodge the projectile from your shield.", logStatusEnd)
+	case EffCorrosion:
+		g.PrintStyled("Your equipment is now free from acid.", logStatusEnd)
+	case EffDig:
+		g.PrintStyled("You no longer feel like an earth dragon.", logStatusEnd)
+	case EffSwap:
+		g.PrintStyled("You no longer feel light-footed.", logStatusEnd)
+	case EffShadows:
+		g.PrintStyled("The shadows leave you.", logStatusEnd)
+		g.ComputeLOS()
+		g.MakeMonstersAware()
+	case EffSlay:
+		g.PrintStyled("You no longer feel extra slaying power.", logStatusEnd)
+	case EffAccurate:
+		g.PrintStyled("You no longer feel accurate.", logStatusEnd)
+	case EffPoison:
+		g.PrintStyled("You are no longer poisoned.", logStatusEnd)
+	case EffFear:
+		g.PrintStyled("You are no longer afraid.", logStatusEnd)
+	case EffRegeneration:
+		g.PrintStyled("You are no longer regenerating.", logStatusEnd)
+	case EffInvisibility:
+		g.PrintStyled("You are no longer translucent.", logStatusEnd)
+	}
+	g.ui.StatusEndAnimation()
+}
+
+func (g *game) MonsterEffectEnd(m *monster, e effect) {
+	switch e {
+	case EffFear:
+		m.Path = nil
+	case EffConfusion, EffLignification:
+		m.Path = m.APath(g, m.Pos, m.Target)
+	}
+	if !m.Visible(g) {
+		return
+	}
+	switch e {
+	case EffSlow:
+		g.Printf("%s is no longer slowed.", m.Kind.Definite(true))
+	case EffLignification:
+		g.Printf("%s is no longer lignified.", m.Kind.Definite(true))
+	case EffConfusion:
+		g.Printf("%s is no longer confused.", m.Kind.Definite(true))
+	case EffPoison:
+		g.Printf("%s is no longer poisoned.", m.Kind.Definite(true))
+	case EffFear:
+		g.Printf("%s is no longer afraid.", m.Kind.Definite(true))
+	case EffRegeneration:
+		g.Printf("%s is no longer regenerating.", m.Kind.Definite(true))
+	case EffInvisibility:
+		g.Printf("%s reappears.", m.Kind.Definite(true))
+		g.StopAuto()
+	case EffSwift:
+		g.Printf("%s slows down.", m.Kind.Definite(true))
+	}
+}
+
+func (effs effects) Sorted() []effect {
+	es := []effect{}
+	for i := 0; i < NumEffects; i++ {
+		if effs.Has(effect(i)) {
+			es = append(es, effect(i))
+		}
+	}
+	return es
+}
+
+func (effs effects) Text(e effect) string {
+	if effs[e].Intensity > 1 {
+		return fmt.Sprintf("%s(%d)", e, effs[e].Intensity)
+	}
+	return e.String()
+}
+
+func (effs effects) ShortText(e effect) string {
+	if effs[e].Intensity > 1 {
+		return fmt.Sprintf("%s(%d)", e.Short(), effs[e].Intensity)
+	}
+	return e.Short()
+}
@@ -12,6 +12,7 @@ func init() {
 	gob.Register(&simpleEvent{})
 	gob.Register(&monsterEvent{})
 	gob.Register(&cloudEvent{})
+	gob.Register(&effectEvent{})
 	gob.Register(armour(0))
 	gob.Register(weapon(0))
 	gob.Register(shield(0))
@@ -32,12 +33,17 @@ func (g *game) GameSave() ([]byte, error) {
 }
 
 type config struct {
-	RuneNormalModeKeys map[rune]keyAction
-	RuneTargetModeKeys map[rune]keyAction
-	DarkLOS            bool
-	Small              bool
-	Tiles              bool
-	Version            string
+	RuneNormalModeKeys    map[rune]keyAction
+	RuneTargetModeKeys    map[rune]keyAction
+	DarkLOS               bool
+	Small                 bool
+	Tiles                 bool
+	Version               string
+	ExploreSkipItems      bool
+	ExploreThroughHazards bool
+	ExploreWaitSleepers   bool
+	ExploreNoStairs       bool
+	AutoFightHP           int
 }
 
 func (c *config) ConfigSave() ([]byte, error) {
@@ -50,6 +56,57 @@ func (c *config) ConfigSave() ([]byte, error) {
 	return data.Bytes(), nil
 }
 
+func (b *bones) BonesSave() ([]byte, error) {
+	data := bytes.Buffer{}
+	enc := gob.NewEncoder(&data)
+	err := enc.Encode(b)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write(data.Bytes())
+	w.Close()
+	return buf.Bytes(), nil
+}
+
+func (g *game) DecodeBones(data []byte) (*bones, error) {
+	buf := bytes.NewReader(data)
+	r, err := zlib.NewReader(buf)
+	if err != nil {
+		return nil, err
+	}
+	dec := gob.NewDecoder(r)
+	b := &bones{}
+	err = dec.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	r.Close()
+	return b, nil
+}
+
+func (st *scoreTable) ScoresSave() ([]byte, error) {
+	data := bytes.Buffer{}
+	enc := gob.NewEncoder(&data)
+	err := enc.Encode(st)
+	if err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+func (g *game) DecodeScores(data []byte) (*scoreTable, error) {
+	buf := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buf)
+	st := &scoreTable{}
+	err := dec.Decode(st)
+	if err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
 func (g *game) DecodeGameSave(data []byte) (*game, error) {
 	buf := bytes.NewReader(data)
 	r, err := zlib.NewReader(buf)
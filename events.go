@@ -45,22 +45,6 @@ type simpleAction int
 
 const (
 	PlayerTurn simpleAction = iota
-	Teleportation
-	BerserkEnd
-	SlowEnd
-	ExhaustionEnd
-	HasteEnd
-	EvasionEnd
-	LignificationEnd
-	ConfusionEnd
-	NauseaEnd
-	DisabledShieldEnd
-	CorrosionEnd
-	DigEnd
-	SwapEnd
-	ShadowsEnd
-	SlayEnd
-	AccurateEnd
 	BlockEnd
 )
 
@@ -102,6 +86,7 @@ func (sev *simpleEvent) Action(g *game) {
 	switch sev.EAction {
 	case PlayerTurn:
 		g.ComputeNoise()
+		g.Reinforce()
 		g.LogNextTick = g.LogIndex
 		g.AutoNext = g.AutoPlayer(sev)
 		if g.AutoNext {
@@ -113,106 +98,6 @@ func (sev *simpleEvent) Action(g *game) {
 			return
 		}
 		g.TurnStats()
-	case Teleportation:
-		if !g.Player.HasStatus(StatusLignification) {
-			g.Teleportation(sev)
-		} else {
-			g.Print("Lignification has prevented teleportation.")
-		}
-		g.Player.Statuses[StatusTele] = 0
-	case BerserkEnd:
-		g.Player.Statuses[StatusBerserk] = 0
-		g.Player.Statuses[StatusSlow]++
-		g.Player.Statuses[StatusExhausted] = 1
-		g.Player.HP -= int(10 * g.Player.HP / Max(g.Player.HPMax(), g.Player.HP))
-		g.PrintStyled("You are no longer berserk.", logStatusEnd)
-		g.PushEvent(&simpleEvent{ERank: sev.Rank() + 90 + RandInt(30), EAction: SlowEnd})
-		g.PushEvent(&simpleEvent{ERank: sev.Rank() + 270 + RandInt(60), EAction: ExhaustionEnd})
-		g.ui.StatusEndAnimation()
-	case SlowEnd:
-		g.Player.Statuses[StatusSlow]--
-		if g.Player.Statuses[StatusSlow] <= 0 {
-			g.PrintStyled("You no longer feel slow.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-		}
-	case ExhaustionEnd:
-		g.PrintStyled("You no longer feel exhausted.", logStatusEnd)
-		g.Player.Statuses[StatusExhausted] = 0
-		g.ui.StatusEndAnimation()
-	case HasteEnd:
-		g.Player.Statuses[StatusSwift]--
-		if g.Player.Statuses[StatusSwift] == 0 {
-			g.PrintStyled("You no longer feel speedy.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-		}
-	case EvasionEnd:
-		g.Player.Statuses[StatusAgile]--
-		if g.Player.Statuses[StatusAgile] == 0 {
-			g.PrintStyled("You no longer feel agile.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-		}
-	case LignificationEnd:
-		g.Player.Statuses[StatusLignification]--
-		g.Player.HP -= int(10 * g.Player.HP / Max(g.Player.HPMax(), g.Player.HP))
-		if g.Player.Statuses[StatusLignification] == 0 {
-			g.PrintStyled("You no longer feel attached to the ground.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-		}
-	case ConfusionEnd:
-		g.PrintStyled("You no longer feel confused.", logStatusEnd)
-		g.Player.Statuses[StatusConfusion] = 0
-		g.ui.StatusEndAnimation()
-	case NauseaEnd:
-		g.PrintStyled("You no longer feel sick.", logStatusEnd)
-		g.Player.Statuses[StatusNausea] = 0
-		g.ui.StatusEndAnimation()
-	case DisabledShieldEnd:
-		g.PrintStyled("You manage to dislodge the projectile from your shield.", logStatusEnd)
-		g.Player.Statuses[StatusDisabledShield] = 0
-		g.ui.StatusEndAnimation()
-	case CorrosionEnd:
-		g.Player.Statuses[StatusCorrosion]--
-		if g.Player.Statuses[StatusCorrosion] == 0 {
-			g.PrintStyled("Your equipment is now free from acid.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-		}
-	case DigEnd:
-		g.Player.Statuses[StatusDig]--
-		if g.Player.Statuses[StatusDig] == 0 {
-			g.PrintStyled("You no longer feel like an earth dragon.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-		}
-	case SwapEnd:
-		g.Player.Statuses[StatusSwap]--
-		if g.Player.Statuses[StatusSwap] == 0 {
-			g.PrintStyled("You no longer feel light-footed.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-		}
-	case ShadowsEnd:
-		g.Player.Statuses[StatusShadows]--
-		if g.Player.Statuses[StatusShadows] == 0 {
-			g.PrintStyled("The shadows leave you.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-			g.ComputeLOS()
-			g.MakeMonstersAware()
-		}
-	case SlayEnd:
-		if g.Player.Statuses[StatusSlay] <= 0 {
-			break
-		}
-		g.Player.Statuses[StatusSlay]--
-		if g.Player.Statuses[StatusSlay] == 0 {
-			g.PrintStyled("You no longer feel extra slaying power.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-			g.ComputeLOS()
-			g.MakeMonstersAware()
-		}
-	case AccurateEnd:
-		g.Player.Statuses[StatusAccurate]--
-		if g.Player.Statuses[StatusAccurate] == 0 {
-			g.PrintStyled("You no longer feel accurate.", logStatusEnd)
-			g.ui.StatusEndAnimation()
-		}
 	case BlockEnd:
 		g.Player.Blocked = false
 	}
@@ -222,10 +107,6 @@ type monsterAction int
 
 const (
 	MonsterTurn monsterAction = iota
-	MonsConfusionEnd
-	MonsExhaustionEnd
-	MonsSlowEnd
-	MonsLignificationEnd
 )
 
 type monsterEvent struct {
@@ -245,41 +126,6 @@ func (mev *monsterEvent) Action(g *game) {
 		if mons.Exists() {
 			mons.HandleTurn(g, mev)
 		}
-	case MonsConfusionEnd:
-		mons := g.Monsters[mev.NMons]
-		if mons.Exists() {
-			mons.Statuses[MonsConfused] = 0
-			if g.Player.LOS[mons.Pos] {
-				g.Printf("The %s is no longer confused.", mons.Kind)
-			}
-			mons.Path = mons.APath(g, mons.Pos, mons.Target)
-		}
-	case MonsLignificationEnd:
-		mons := g.Monsters[mev.NMons]
-		if mons.Exists() {
-			mons.Statuses[MonsLignified] = 0
-			if g.Player.LOS[mons.Pos] {
-				g.Printf("%s is no longer lignified.", mons.Kind.Definite(true))
-			}
-			mons.Path = mons.APath(g, mons.Pos, mons.Target)
-		}
-	case MonsSlowEnd:
-		mons := g.Monsters[mev.NMons]
-		if mons.Exists() {
-			mons.Statuses[MonsSlow]--
-			if g.Player.LOS[mons.Pos] {
-				g.Printf("%s is no longer slowed.", mons.Kind.Definite(true))
-			}
-		}
-	case MonsExhaustionEnd:
-		mons := g.Monsters[mev.NMons]
-		if mons.Exists() {
-			mons.Statuses[MonsExhausted]--
-			//if mons.State != Resting && g.Player.LOS[mons.Pos] &&
-			//(mons.Kind.Ranged() || mons.Kind.Smiting()) && mons.Pos.Distance(g.Player.Pos) > 1 {
-			//g.Printf("%s is ready to fire again.", mons.Kind.Definite(true))
-			//}
-		}
 	}
 }
 
@@ -296,6 +142,8 @@ const (
 	ObstructionProgression
 	FireProgression
 	NightProgression
+	GasProgression
+	FoliageProgression
 )
 
 type cloudEvent struct {
@@ -311,8 +159,9 @@ func (cev *cloudEvent) Rank() int {
 func (cev *cloudEvent) Action(g *game) {
 	switch cev.EAction {
 	case CloudEnd:
-		delete(g.Clouds, cev.Pos)
-		g.ComputeLOS()
+		if cld, ok := g.Clouds[cev.Pos]; ok && cld == CloudFog {
+			g.RemoveCloud(cev.Pos)
+		}
 	case ObstructionEnd:
 		if !g.Player.LOS[cev.Pos] && g.Dungeon.Cell(cev.Pos).T == WallCell {
 			g.WrongWall[cev.Pos] = !g.WrongWall[cev.Pos]
@@ -335,14 +184,18 @@ func (cev *cloudEvent) Action(g *game) {
 		}
 		g.PushEvent(&cloudEvent{ERank: cev.Rank() + 200 + RandInt(50), EAction: ObstructionProgression})
 	case FireProgression:
-		if _, ok := g.Clouds[cev.Pos]; !ok {
+		if cld, ok := g.Clouds[cev.Pos]; !ok || cld != CloudFire {
 			break
 		}
 		g.BurnCreature(cev.Pos, cev)
 		if RandInt(10) == 0 {
-			delete(g.Clouds, cev.Pos)
-			g.Fog(cev.Pos, 1, &simpleEvent{ERank: cev.Rank()})
-			g.ComputeLOS()
+			g.RemoveCloud(cev.Pos)
+			if RandInt(4) == 0 {
+				// wet foliage
+				g.Gas(cev.Pos, CloudSteam, 3, cev)
+			} else {
+				g.Fog(cev.Pos, 1, &simpleEvent{ERank: cev.Rank()})
+			}
 			break
 		}
 		for _, pos := range g.Dungeon.FreeNeighbors(cev.Pos) {
@@ -353,28 +206,31 @@ func (cev *cloudEvent) Action(g *game) {
 		}
 		cev.Renew(g, 10)
 	case NightProgression:
-		if _, ok := g.Clouds[cev.Pos]; !ok {
+		if cld, ok := g.Clouds[cev.Pos]; !ok || cld != CloudNight {
 			break
 		}
 		g.MakeCreatureSleep(cev.Pos, cev)
 		if RandInt(20) == 0 {
-			delete(g.Clouds, cev.Pos)
-			g.ComputeLOS()
+			g.RemoveCloud(cev.Pos)
 			break
 		}
 		cev.Renew(g, 10)
+	case GasProgression:
+		g.GasProgression(cev)
+	case FoliageProgression:
+		g.FoliageProgression(cev)
+		cev.Renew(g, FoliageGrowthDelay)
 	}
 }
 
 func (g *game) MakeCreatureSleep(pos position, ev event) {
 	if pos == g.Player.Pos {
-		g.Player.Statuses[StatusSlow]++
-		g.PushEvent(&simpleEvent{ERank: ev.Rank() + 30 + RandInt(10), EAction: SlowEnd})
+		g.PlayerAddEffect(EffSlow, EffSlow.Duration())
 		g.Print("The clouds of night make you sleepy.")
 		return
 	}
 	mons := g.MonsterAt(pos)
-	if !mons.Exists() || (RandInt(2) == 0 && mons.Status(MonsExhausted)) {
+	if !mons.Exists() || (RandInt(2) == 0 && mons.HasEffect(EffExhausted)) {
 		// do not always make already exhausted monsters sleep (they were probably awaken)
 		return
 	}
@@ -424,8 +280,10 @@ func (g *game) Burn(pos position, ev event) {
 	g.Stats.Burns++
 	foliage := true
 	delete(g.Fungus, pos)
+	g.BurntFoliage[pos] = true
 	if _, ok := g.Doors[pos]; ok {
 		delete(g.Doors, pos)
+		delete(g.BurntFoliage, pos)
 		foliage = false
 		g.Print("The door vanishes in flames.")
 	}
@@ -0,0 +1,126 @@
+package main
+
+// FoliageGrowthDelay is the fixed delay between two foliage growth ticks.
+const FoliageGrowthDelay = 250
+
+func (g *game) InitFoliageGrowth() {
+	g.BurntFoliage = map[position]bool{}
+	g.FoliageMax = len(g.Fungus) + len(g.Fungus)/4 + 10
+	g.PushEvent(&cloudEvent{ERank: g.Turn + FoliageGrowthDelay, EAction: FoliageProgression})
+}
+
+func (g *game) FoliageGrowthCandidate(pos position) bool {
+	if !pos.valid() || g.Dungeon.Cell(pos).T != FreeCell {
+		return false
+	}
+	if _, ok := g.Fungus[pos]; ok {
+		return false
+	}
+	if _, ok := g.Clouds[pos]; ok {
+		return false
+	}
+	if g.IsDoor(pos) {
+		return false
+	}
+	if _, ok := g.Stairs[pos]; ok {
+		return false
+	}
+	if _, ok := g.MagicalStones[pos]; ok {
+		return false
+	}
+	return true
+}
+
+func (g *game) GrowFoliage(pos position) bool {
+	if !g.FoliageGrowthCandidate(pos) {
+		return false
+	}
+	g.Fungus[pos] = foliage
+	delete(g.BurntFoliage, pos)
+	if !g.Player.LOS[pos] {
+		g.WrongFoliage[pos] = !g.WrongFoliage[pos]
+		return false
+	}
+	return true
+}
+
+func (g *game) FoliageProgression(ev event) {
+	seen := false
+	// cells are visited in index order, not in map order, so that growth
+	// is reproducible
+	// regrow burnt foliage
+	for i := range g.Dungeon.Cells {
+		pos := idxtopos(i)
+		if !g.BurntFoliage[pos] {
+			continue
+		}
+		if RandInt(4) > 0 {
+			continue
+		}
+		if !g.FoliageGrowthCandidate(pos) {
+			if _, ok := g.Clouds[pos]; !ok {
+				delete(g.BurntFoliage, pos)
+			}
+			continue
+		}
+		if g.GrowFoliage(pos) {
+			seen = true
+		}
+	}
+	// spread into free cells next to dense foliage
+	nb := make([]position, 0, 8)
+	for i := range g.Dungeon.Cells {
+		if len(g.Fungus) >= g.FoliageMax {
+			break
+		}
+		pos := idxtopos(i)
+		if _, ok := g.Fungus[pos]; !ok {
+			continue
+		}
+		if RandInt(20) > 0 {
+			continue
+		}
+		nb = pos.Neighbors(nb, g.FoliageGrowthCandidate)
+		if len(nb) == 0 {
+			continue
+		}
+		npos := nb[RandInt(len(nb))]
+		count := 0
+		for _, p := range npos.Neighbors(nb, position.valid) {
+			if _, ok := g.Fungus[p]; ok {
+				count++
+			}
+		}
+		if count < 3 {
+			continue
+		}
+		if g.GrowFoliage(npos) {
+			seen = true
+		}
+	}
+	// plant monsters seed new growth
+	for _, mons := range g.Monsters {
+		if !mons.Exists() || !mons.Kind.SeedsFoliage() || RandInt(2) == 0 {
+			continue
+		}
+		nb = mons.Pos.Neighbors(nb, g.FoliageGrowthCandidate)
+		if len(nb) == 0 {
+			continue
+		}
+		if g.GrowFoliage(nb[RandInt(len(nb))]) {
+			seen = true
+		}
+	}
+	if seen {
+		g.ComputeLOS()
+	}
+}
+
+func (mk monsterKind) SeedsFoliage() bool {
+	switch mk {
+	case MonsTreeMushroom, MonsSatowalgaPlant:
+		return true
+	default:
+		return false
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"container/heap"
-	"fmt"
 )
 
 var Version string = "v0.14-dev"
@@ -29,11 +28,19 @@ type game struct {
 	Rods                map[position]rod
 	Stairs              map[position]stair
 	Clouds              map[position]cloud
+	GasDensity          map[position]int
+	GasPending          map[position]bool
 	Fungus              map[position]vegetation
-	Doors               map[position]bool
+	BurntFoliage        map[position]bool
+	FoliageMax          int
+	Doors               map[position]door
+	Keys                map[position]bool
 	TemporalWalls       map[position]bool
 	MagicalStones       map[position]stone
 	GeneratedUniques    map[monsterBand]int
+	GeneratedBosses     map[boss]bool
+	Bones               *bones
+	GhostStatus         ghostStatus
 	GeneratedEquipables map[equipable]bool
 	GeneratedRods       map[rod]bool
 	GenPlan             [MaxDepth + 1]genFlavour
@@ -43,11 +50,16 @@ type game struct {
 	WrongFoliage        map[position]bool
 	WrongDoor           map[position]bool
 	ExclusionsMap       map[position]bool
+	VisitedItems        map[position]bool
+	Annotations         map[position]string
 	Noise               map[position]bool
+	NoiseCues           map[position]bool
+	PresenceCueTurn     int
 	DreamingMonster     map[position]bool
 	Resting             bool
 	RestingTurns        int
 	Autoexploring       bool
+	AutoFighting        bool
 	DijkstraMapRebuild  bool
 	Targeting           position
 	AutoTarget          position
@@ -56,6 +68,8 @@ type game struct {
 	AutoNext            bool
 	DrawBuffer          []UICell
 	drawBackBuffer      []UICell
+	replayFile          string
+	scores              *scoreTable
 	DrawLog             []drawFrame
 	Log                 []logEntry
 	LogIndex            int
@@ -63,6 +77,9 @@ type game struct {
 	InfoEntry           string
 	Stats               stats
 	Boredom             int
+	LevelNoise          int
+	ReinforcementTurn   int
+	ReinforcementWarned bool
 	Quit                bool
 	Wizard              bool
 	WizardMap           bool
@@ -76,6 +93,8 @@ type startOpts struct {
 	StoneLevel    int
 	SpecialBands  map[int][]monsterBandData
 	UnstableLevel int
+	Background    background
+	Difficulty    difficulty
 }
 
 func (g *game) FreeCell() position {
@@ -100,6 +119,9 @@ func (g *game) FreeCell() position {
 		if mons.Exists() {
 			continue
 		}
+		if d, ok := g.Doors[pos]; ok && d == DoorLocked {
+			continue
+		}
 		return pos
 	}
 }
@@ -161,7 +183,7 @@ func (g *game) FreeCellForStatic() position {
 		if mons.Exists() {
 			continue
 		}
-		if g.Doors[pos] {
+		if g.IsDoor(pos) {
 			continue
 		}
 		if g.Simellas[pos] > 0 {
@@ -170,6 +192,9 @@ func (g *game) FreeCellForStatic() position {
 		if _, ok := g.Collectables[pos]; ok {
 			continue
 		}
+		if g.Keys[pos] {
+			continue
+		}
 		if _, ok := g.Stairs[pos]; ok {
 			continue
 		}
@@ -208,6 +233,9 @@ func (g *game) FreeCellForMonster() position {
 		if mons.Exists() {
 			continue
 		}
+		if d, ok := g.Doors[pos]; ok && d == DoorLocked {
+			continue
+		}
 		return pos
 	}
 }
@@ -229,6 +257,9 @@ func (g *game) FreeCellForBandMonster(pos position) position {
 		if mons.Exists() {
 			continue
 		}
+		if d, ok := g.Doors[pos]; ok && d == DoorLocked {
+			continue
+		}
 		return pos
 	}
 }
@@ -293,63 +324,19 @@ func (g *game) GenDungeon() {
 
 func (g *game) InitPlayer() {
 	g.Player = &player{
-		HP:        42,
-		MP:        3,
-		Simellas:  0,
-		Aptitudes: map[aptitude]bool{},
+		Simellas:   0,
+		Aptitudes:  map[aptitude]bool{},
+		Background: g.Opts.Background,
 	}
 	g.Player.Consumables = map[consumable]int{
 		HealWoundsPotion: 1,
 	}
-	switch RandInt(7) {
-	case 0:
-		g.Player.Consumables[ExplosiveMagara] = 1
-	case 1:
-		g.Player.Consumables[NightMagara] = 1
-	case 2:
-		g.Player.Consumables[TeleportMagara] = 1
-	case 3:
-		g.Player.Consumables[SlowingMagara] = 1
-	case 4:
-		g.Player.Consumables[ConfuseMagara] = 1
-	default:
-		g.Player.Consumables[ConfusingDart] = 2
-	}
-	switch RandInt(12) {
-	case 0, 1:
-		g.Player.Consumables[TeleportationPotion] = 1
-	case 2, 3:
-		g.Player.Consumables[BerserkPotion] = 1
-	case 4:
-		g.Player.Consumables[SwiftnessPotion] = 1
-	case 5:
-		g.Player.Consumables[LignificationPotion] = 1
-	case 6:
-		g.Player.Consumables[WallPotion] = 1
-	case 7:
-		g.Player.Consumables[CBlinkPotion] = 1
-	case 8:
-		g.Player.Consumables[DigPotion] = 1
-	case 9:
-		g.Player.Consumables[SwapPotion] = 1
-	case 10:
-		g.Player.Consumables[ShadowsPotion] = 1
-	case 11:
-		g.Player.Consumables[AccuracyPotion] = 1
-	}
-	r := g.RandomRod()
-	items := r.String()
-	for c, n := range g.Player.Consumables {
-		if n == 1 {
-			items += ", " + c.String()
-		} else {
-			items += fmt.Sprintf(", %d %s", n, c.Plural())
-		}
-	}
-	g.StoryPrintf("Started with %s", items)
-	g.Player.Rods = map[rod]rodProps{r: rodProps{r.MaxCharge() - 1}}
-	g.Player.Statuses = map[status]int{}
-	g.Player.Expire = map[status]int{}
+	g.Player.Rods = map[rod]rodProps{}
+	g.InitBackgroundKit()
+	g.Player.HP = g.Player.HPMax()
+	g.Player.MP = g.Player.MPMax()
+	g.StoryPrint(g.BackgroundStoryText())
+	g.Player.Effects = effects{}
 
 	// Testing
 	//g.Player.Aptitudes[AptStealthyLOS] = true
@@ -430,9 +417,11 @@ func (g *game) InitFirstLevel() {
 	g.Targeting = InvalidPos
 	g.GeneratedRods = map[rod]bool{}
 	g.GeneratedEquipables = map[equipable]bool{}
-	g.FoundEquipables = map[equipable]bool{Robe: true, Dagger: true}
+	g.FoundEquipables = map[equipable]bool{Robe: true, Dagger: true, g.Player.Weapon: true}
 	g.GeneratedUniques = map[monsterBand]int{}
+	g.GeneratedBosses = map[boss]bool{}
 	g.Stats.KilledMons = map[monsterKind]int{}
+	g.Stats.KilledChampions = map[monsterKind]int{}
 	g.InitSpecialBands()
 	if RandInt(4) > 0 {
 		g.Opts.UnstableLevel = 1 + RandInt(MaxDepth)
@@ -489,19 +478,41 @@ func (g *game) InitLevel() {
 	g.MonstersPosCache = make([]int, DungeonNCells)
 	g.Player.Pos = g.FreeCellForPlayer()
 
+	// Boss arena
+	arena := InvalidPos
+	lboss, bossLevel := g.LevelBoss()
+	if bossLevel {
+		arena = g.GenBossArena()
+	}
+
+	// Vault
+	g.Keys = map[position]bool{}
+	g.Player.Keys = 0
+	var vault []position
+	if g.Depth > 1 && RandInt(3) == 0 {
+		vault = g.GenVault()
+	}
+
 	g.WrongWall = map[position]bool{}
 	g.WrongFoliage = map[position]bool{}
 	g.WrongDoor = map[position]bool{}
 	g.ExclusionsMap = map[position]bool{}
+	g.VisitedItems = map[position]bool{}
+	g.Annotations = map[position]string{}
 	g.TemporalWalls = map[position]bool{}
 	g.DreamingMonster = map[position]bool{}
 
 	// Monsters
+	g.InitReinforcements()
 	g.BandData = MonsBands
 	if bd, ok := g.Opts.SpecialBands[g.Depth]; ok {
 		g.BandData = bd
 	}
 	g.GenMonsters()
+	if arena.valid() {
+		g.SpawnBoss(lboss, arena)
+	}
+	g.SpawnGhost()
 
 	// Collectables
 	g.Collectables = make(map[position]collectable)
@@ -531,6 +542,10 @@ func (g *game) InitLevel() {
 		g.GenCollectable()
 		g.CollectableScore--
 	}
+	if len(vault) > 0 {
+		g.GenVaultTreasure(vault)
+		g.CollectableScore--
+	}
 
 	// Aptitudes/Mutations
 	if g.Depth == 2 || g.Depth == 5 {
@@ -640,6 +655,8 @@ func (g *game) InitLevel() {
 
 	// clouds
 	g.Clouds = map[position]cloud{}
+	g.GasDensity = map[position]int{}
+	g.GasPending = map[position]bool{}
 
 	// Events
 	if g.Depth == 1 {
@@ -652,6 +669,7 @@ func (g *game) InitLevel() {
 	for i := range g.Monsters {
 		g.PushEvent(&monsterEvent{ERank: g.Turn + RandInt(10), EAction: MonsterTurn, NMons: i})
 	}
+	g.InitFoliageGrowth()
 	if g.Depth == g.Opts.UnstableLevel {
 		g.PrintStyled("You sense magic instability on this level.", logSpecial)
 		for i := 0; i < 15; i++ {
@@ -670,6 +688,10 @@ func (g *game) CleanEvents() {
 		switch iev.Event.(type) {
 		case *monsterEvent:
 		case *cloudEvent:
+		case *effectEvent:
+			if iev.Event.(*effectEvent).NMons == PlayerEffects {
+				heap.Push(evq, iev)
+			}
 		default:
 			heap.Push(evq, iev)
 		}
@@ -688,6 +710,12 @@ func (g *game) StairsSlice() []position {
 }
 
 func (g *game) GenCollectable() {
+	c := g.RandomCollectable()
+	pos := g.FreeCellForStatic()
+	g.Collectables[pos] = collectable{Consumable: c, Quantity: ConsumablesCollectData[c].quantity}
+}
+
+func (g *game) RandomCollectable() consumable {
 	rounds := 100
 	if len(g.LastConsumables) > 3 {
 		g.LastConsumables = g.LastConsumables[1:]
@@ -708,16 +736,13 @@ func (g *game) GenCollectable() {
 			}
 			g.LastConsumables = append(g.LastConsumables, c)
 			g.CollectableScore++
-			pos := g.FreeCellForStatic()
-			g.Collectables[pos] = collectable{Consumable: c, Quantity: data.quantity}
-			return
+			return c
 		}
 	}
-
 }
 
 func (g *game) GenCollectables() {
-	score := g.CollectableScore - 2*(g.Depth-1)
+	score := g.CollectableScore - 2*(g.Depth-1)*g.Opts.Difficulty.CollectablesPercent()/100
 	n := 2
 	if score >= 0 && RandInt(4) == 0 {
 		n--
@@ -885,7 +910,8 @@ func (g *game) AutoPlayer(ev event) bool {
 			if g.DijkstraMapRebuild {
 				if g.AllExplored() {
 					g.Print("You finished exploring.")
-					break
+					g.Autoexploring = false
+					return g.FinishExploring(ev)
 				}
 				sources := g.AutoexploreSources()
 				g.BuildAutoexploreMap(sources)
@@ -896,6 +922,8 @@ func (g *game) AutoPlayer(ev event) bool {
 			}
 			if finished && g.AllExplored() {
 				g.Print("You finished exploring.")
+				g.Autoexploring = false
+				return g.FinishExploring(ev)
 			} else if n == nil {
 				g.Print("You could not safely reach some places.")
 			}
@@ -921,6 +949,20 @@ func (g *game) AutoPlayer(ev event) bool {
 		} else {
 			g.AutoDir = NoDir
 		}
+	} else if g.AutoFighting {
+		switch {
+		case g.ui.ExploreStep():
+			g.Print("Stopping, then.")
+		case g.AutoFightHPLow():
+			g.PrintStyled("Your HP are too low: you stop fighting.", logCritic)
+		default:
+			err := g.AutoFightStep(ev)
+			if err == nil {
+				return true
+			}
+			g.Print(err.Error())
+		}
+		g.AutoFighting = false
 	}
 	return false
 }
@@ -937,6 +979,18 @@ loop:
 				if err != nil {
 					g.PrintfStyled("Error removing save file: %v", logError, err.Error())
 				}
+				if !g.Wizard {
+					err := g.RecordScore(false)
+					if err != nil {
+						g.PrintfStyled("Error writing scores: %v", logError, err.Error())
+					}
+				}
+				if g.BonesWorthy() {
+					err := g.WriteBones()
+					if err != nil {
+						g.PrintfStyled("Error writing bones file: %v", logError, err.Error())
+					}
+				}
 				g.ui.Death()
 				break loop
 			}
@@ -16,3 +16,147 @@ func TestInitLevel(t *testing.T) {
 		}
 	}
 }
+
+func TestReinforcementsAct(t *testing.T) {
+	DisableAnimations = true
+	g := &game{}
+	g.ui = &gameui{g: g}
+	var n int
+	reachable := func() bool {
+		for _, mons := range g.Monsters[n:] {
+			if len(mons.APath(g, mons.Pos, g.Player.Pos)) > 0 {
+				return true
+			}
+		}
+		return false
+	}
+	for i := 0; i < 20; i++ {
+		for depth := 0; depth < 4; depth++ {
+			g.Depth = depth
+			g.InitLevel()
+		}
+		for _, mons := range g.Monsters {
+			mons.HP = 0
+		}
+		n = len(g.Monsters)
+		g.GenReinforcements()
+		if reachable() {
+			// some levels have small isolated areas
+			break
+		}
+	}
+	if !reachable() {
+		t.Fatal("no reachable reinforcements generated")
+	}
+	start := map[int]position{}
+	for _, mons := range g.Monsters[n:] {
+		start[mons.Index] = mons.Pos
+	}
+	moved := func() bool {
+		for _, mons := range g.Monsters[n:] {
+			if mons.Pos != start[mons.Index] {
+				return true
+			}
+		}
+		return false
+	}
+	for i := 0; i < 1000 && g.Events.Len() > 0; i++ {
+		ev := g.PopIEvent().Event
+		if sev, ok := ev.(*simpleEvent); ok && sev.EAction == PlayerTurn {
+			// the player stays still
+			ev.Renew(g, 10)
+			continue
+		}
+		g.Turn = ev.Rank()
+		g.Ev = ev
+		ev.Action(g)
+		if moved() {
+			return
+		}
+	}
+	t.Error("reinforcements did not move")
+}
+
+func TestGasSingleProgression(t *testing.T) {
+	DisableAnimations = true
+	g := &game{}
+	g.ui = &gameui{g: g}
+	for depth := 0; depth < 2; depth++ {
+		g.Depth = depth
+		g.InitLevel()
+	}
+	pos := g.FreeCellForStatic()
+	ev := &simpleEvent{ERank: g.Turn}
+	g.Ev = ev
+	g.Gas(pos, CloudPoison, 5, ev)
+	g.RemoveCloud(pos)
+	g.Gas(pos, CloudPoison, 5, ev)
+	count := 0
+	for _, iev := range *g.Events {
+		if cev, ok := iev.Event.(*cloudEvent); ok && cev.EAction == GasProgression && cev.Pos == pos {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Bad number of gas progression events: %d", count)
+	}
+}
+
+func TestBossBand(t *testing.T) {
+	DisableAnimations = true
+	g := &game{}
+	g.ui = &gameui{g: g}
+	for depth := 0; depth < 2; depth++ {
+		g.Depth = depth
+		g.InitLevel()
+	}
+	ev := &simpleEvent{ERank: g.Turn}
+	g.Ev = ev
+	n := len(g.BandData)
+	mons := g.SpawnBoss(BossGoblinWarlord, g.FreeCellForMonster())
+	if g.Bands[mons.Band] != monsterBand(n) || !g.BandData[g.Bands[mons.Band]].Band {
+		t.Errorf("Bad boss band: %d", g.Bands[mons.Band])
+	}
+	if len(MonsBands) != int(UXVariedWarriors)+1 {
+		t.Errorf("Bad number of default bands: %d", len(MonsBands))
+	}
+}
+
+func TestEffectStackCount(t *testing.T) {
+	DisableAnimations = true
+	g := &game{}
+	g.ui = &gameui{g: g}
+	for depth := 0; depth < 2; depth++ {
+		g.Depth = depth
+		g.InitLevel()
+	}
+	ev := &simpleEvent{ERank: g.Turn}
+	g.Ev = ev
+	g.PlayerAddEffect(EffSlow, 10)
+	g.PlayerAddEffect(EffSlow, 30)
+	type tableTest struct {
+		rank      int
+		intensity int
+	}
+	table := []tableTest{
+		{ev.Rank() + 5, 2},
+		{ev.Rank() + 20, 1},
+		{ev.Rank() + 40, 0},
+	}
+	for _, test := range table {
+		for g.Events.Len() > 0 {
+			iev := g.PopIEvent()
+			if iev.Event.Rank() > test.rank {
+				g.PushEvent(iev.Event)
+				break
+			}
+			if eev, ok := iev.Event.(*effectEvent); ok {
+				g.Ev = eev
+				eev.Action(g)
+			}
+		}
+		if g.Player.Effects.Intensity(EffSlow) != test.intensity {
+			t.Errorf("Bad slow intensity at %d: %d", test.rank, g.Player.Effects.Intensity(EffSlow))
+		}
+	}
+}
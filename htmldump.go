@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+)
+
+// HTMLDump enables writing an HTML character dump along with the text one.
+var HTMLDump bool
+
+// HTMLDumpLogLines is the number of last messages shown in the HTML dump.
+const HTMLDumpLogLines = 25
+
+func (ui *gameui) HTMLStyle() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "body { background: %s; color: %s; font-family: monospace; }\n",
+		ui.Color16(ColorBg), ui.Color16(ColorFg))
+	fmt.Fprintf(buf, "pre { line-height: 1.2; }\n")
+	fmt.Fprintf(buf, "summary { cursor: pointer; color: %s; }\n", ui.Color16(ColorCyan))
+	fmt.Fprintf(buf, "h2 { font-size: 1em; color: %s; }\n", ui.Color16(ColorCyan))
+	for i := uicolor(0); i < 16; i++ {
+		fmt.Fprintf(buf, ".f%d { color: %s; }\n.b%d { background: %s; }\n", i, i.String(), i, i.String())
+	}
+	return buf.String()
+}
+
+// HTMLSpan writes text with the given colors.
+func (ui *gameui) HTMLSpan(w io.Writer, text string, fg, bg uicolor) {
+	fmt.Fprintf(w, `<span class="f%d b%d">%s</span>`, ui.Color16(fg), ui.Color16(bg), html.EscapeString(text))
+}
+
+// HTMLDungeon writes the map with the colors used on screen, merging
+// neighbour cells with the same colors.
+func (ui *gameui) HTMLDungeon(w io.Writer) {
+	for y := 0; y < DungeonHeight; y++ {
+		run := []rune{}
+		var rfg, rbg uicolor
+		for x := 0; x < DungeonWidth; x++ {
+			r, fg, bg := ui.PositionDrawing(position{X: x, Y: y})
+			if len(run) > 0 && (fg != rfg || bg != rbg) {
+				ui.HTMLSpan(w, string(run), rfg, rbg)
+				run = run[:0]
+			}
+			run = append(run, r)
+			rfg, rbg = fg, bg
+		}
+		ui.HTMLSpan(w, string(run), rfg, rbg)
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+func (ui *gameui) HTMLLog(w io.Writer) {
+	g := ui.g
+	for i := len(g.Log) - HTMLDumpLogLines; i < len(g.Log); i++ {
+		if i < 0 {
+			continue
+		}
+		e := g.Log[i]
+		fmt.Fprintf(w, `<span class="f%d">%s</span> `, ui.Color16(ColorFgPlace), html.EscapeString(e.Stamp()))
+		fmt.Fprintf(w, `<span class="f%d">%s</span>`+"\n", ui.Color16(ui.LogColor(e)), html.EscapeString(e.String()))
+	}
+}
+
+func htmlDetails(w io.Writer, title, text string, open bool) {
+	if open {
+		fmt.Fprintf(w, "<details open>")
+	} else {
+		fmt.Fprintf(w, "<details>")
+	}
+	fmt.Fprintf(w, "<summary>%s</summary>\n<pre>%s</pre>\n</details>\n", html.EscapeString(title), html.EscapeString(text))
+}
+
+// HTMLDump returns a self-contained HTML character dump, with the final
+// map and the last messages in color.
+func (ui *gameui) HTMLDump() string {
+	g := ui.g
+	buf := &bytes.Buffer{}
+	title := fmt.Sprintf("Boohu version %s character file", Version)
+	fmt.Fprintf(buf, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(buf, "<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n", html.EscapeString(title), ui.HTMLStyle())
+	fmt.Fprintf(buf, "<h2>%s</h2>\n", html.EscapeString(title))
+	character := &bytes.Buffer{}
+	g.DumpCharacter(character)
+	htmlDetails(buf, "Character", character.String(), true)
+	fmt.Fprintf(buf, "<details open><summary>Last messages</summary>\n<pre>")
+	ui.HTMLLog(buf)
+	fmt.Fprintf(buf, "</pre>\n</details>\n")
+	fmt.Fprintf(buf, "<details open><summary>Dungeon</summary>\n<pre>")
+	ui.HTMLDungeon(buf)
+	fmt.Fprintf(buf, "</pre>\n</details>\n")
+	if len(g.Annotations) > 0 {
+		htmlDetails(buf, "Map notes", g.DumpAnnotations(), false)
+	}
+	htmlDetails(buf, "Killed monsters", g.DumpedKilledMonsters(), false)
+	htmlDetails(buf, "Timeline", g.DumpStory(), false)
+	stats := &bytes.Buffer{}
+	g.DetailedStatistics(stats)
+	htmlDetails(buf, "Statistics", stats.String(), false)
+	fmt.Fprintf(buf, "</body>\n</html>\n")
+	return buf.String()
+}
@@ -0,0 +1,340 @@
+// +build !js
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type imageOptions struct {
+	Tiles   bool   // use the tile set for map cells
+	FPS     int    // frames per second of animated images
+	Turns   string // turn range, as in "100-200"
+	Palette string // file with the 16 palette colors, one per line
+}
+
+// imageExporter renders replay frames or a level map into images, using the
+// tile set and the bitmap font of the graphical backends.
+type imageExporter struct {
+	ui      *gameui
+	tiles   bool
+	fps     int
+	from    int
+	to      int // negative means no limit
+	palette color.Palette
+	width   int // in cells
+	height  int
+	cw      int // cell size in pixels
+	ch      int
+	screen  []UICell
+	masks   map[tileKey][]bool
+}
+
+type tileKey struct {
+	R    rune
+	Tile bool
+}
+
+// ExportImage exports a replay, or the current level of the saved game if
+// replayFile is empty, into a PNG snapshot or an animated GIF, depending on
+// the extension of out.
+func ExportImage(replayFile, out string, opts imageOptions) error {
+	g := &game{}
+	ui := &gameui{g: g}
+	ex := &imageExporter{ui: ui, tiles: opts.Tiles, fps: opts.FPS, masks: map[tileKey][]bool{}}
+	if ex.fps < 1 || ex.fps > 50 {
+		return fmt.Errorf("frame rate should be between 1 and 50")
+	}
+	var err error
+	ex.from, ex.to, err = ParseTurnRange(opts.Turns)
+	if err != nil {
+		return err
+	}
+	ex.palette, err = LoadPalette(opts.Palette)
+	if err != nil {
+		return err
+	}
+	if _, err := ex.mask(' ', false); err != nil {
+		return err
+	}
+	ext := strings.ToLower(filepath.Ext(out))
+	if ext != ".png" && ext != ".gif" {
+		return fmt.Errorf("unknown image format %q (use .png or .gif)", ext)
+	}
+	var frames []drawFrame
+	if replayFile != "" {
+		err = g.LoadReplay(replayFile)
+		if err != nil {
+			return fmt.Errorf("loading replay: %v", err)
+		}
+		frames = g.DrawLog
+		if len(frames) == 0 {
+			return errors.New("empty replay")
+		}
+		ex.width, ex.height = frameSize(frames)
+		ex.screen = make([]UICell, ex.width*ex.height)
+	} else {
+		load, err := g.Load()
+		if !load || err != nil {
+			return fmt.Errorf("loading saved game: %v", err)
+		}
+		g.ui = ui
+		LinkColors()
+		ex.DrawLevel()
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	switch {
+	case ext == ".gif" && frames != nil:
+		err = ex.WriteGIF(w, frames)
+	case ext == ".gif":
+		err = gif.Encode(w, ex.Render(ex.Bounds()), nil)
+	default:
+		err = ex.WritePNG(w, frames)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s: %v", out, err)
+	}
+	return nil
+}
+
+// ParseTurnRange parses turn ranges of the form "from-to", "from-", "-to" or
+// "turn". An empty range means all turns.
+func ParseTurnRange(s string) (from, to int, err error) {
+	to = -1
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return from, to, nil
+	}
+	bounds := strings.SplitN(s, "-", 2)
+	if bounds[0] != "" {
+		from, err = strconv.Atoi(bounds[0])
+		if err != nil {
+			return from, to, fmt.Errorf("invalid turn range %q", s)
+		}
+	}
+	if len(bounds) == 1 {
+		return from, from, nil
+	}
+	if bounds[1] != "" {
+		to, err = strconv.Atoi(bounds[1])
+		if err != nil || to < from {
+			return from, to, fmt.Errorf("invalid turn range %q", s)
+		}
+	}
+	return from, to, nil
+}
+
+// LoadPalette reads the 16 colors of the palette from a file, one #rrggbb
+// color per line. An empty file name means the default solarized palette.
+func LoadPalette(file string) (color.Palette, error) {
+	p := color.Palette{}
+	if file == "" {
+		for c := uicolor(0); c < 16; c++ {
+			p = append(p, c.Color())
+		}
+		return p, nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "#")
+		if line == "" {
+			continue
+		}
+		rgb, err := strconv.ParseUint(line, 16, 32)
+		if err != nil || len(line) != 6 {
+			return nil, fmt.Errorf("invalid palette color %q", line)
+		}
+		p = append(p, color.RGBA{uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb), 255})
+	}
+	if len(p) != 16 {
+		return nil, fmt.Errorf("palette should have 16 colors, found %d", len(p))
+	}
+	return p, nil
+}
+
+// mask returns for each pixel of the image of r whether it is drawn with the
+// foreground color.
+func (ex *imageExporter) mask(r rune, tile bool) ([]bool, error) {
+	if m, ok := ex.masks[tileKey{r, tile}]; ok {
+		return m, nil
+	}
+	img, err := TileImage(r, tile)
+	if err != nil {
+		return nil, fmt.Errorf("decoding image for %q: %v", r, err)
+	}
+	rect := img.Bounds()
+	if ex.cw == 0 {
+		ex.cw, ex.ch = rect.Dx(), rect.Dy()
+	}
+	m := make([]bool, ex.cw*ex.ch)
+	for y := 0; y < ex.ch && y < rect.Dy(); y++ {
+		for x := 0; x < ex.cw && x < rect.Dx(); x++ {
+			r, _, _, _ := img.At(rect.Min.X+x, rect.Min.Y+y).RGBA()
+			m[y*ex.cw+x] = r != 0
+		}
+	}
+	ex.masks[tileKey{r, tile}] = m
+	return m, nil
+}
+
+func (ex *imageExporter) Bounds() image.Rectangle {
+	return image.Rect(0, 0, ex.width, ex.height)
+}
+
+// DrawLevel fills the screen with the map of the current level.
+func (ex *imageExporter) DrawLevel() {
+	ex.width, ex.height = DungeonWidth, DungeonHeight
+	ex.screen = make([]UICell, ex.width*ex.height)
+	for i := range ex.screen {
+		pos := idxtopos(i)
+		r, fg, bg := ex.ui.PositionDrawing(pos)
+		ex.screen[i] = UICell{R: r, Fg: fg, Bg: bg, InMap: true}
+	}
+}
+
+// Apply draws a replay frame on the screen and returns the rectangle of
+// cells that changed.
+func (ex *imageExporter) Apply(df drawFrame) image.Rectangle {
+	dirty := image.Rectangle{}
+	for _, dr := range df.Draws {
+		if dr.X < 0 || dr.X >= ex.width || dr.Y < 0 || dr.Y >= ex.height {
+			continue
+		}
+		ex.screen[dr.Y*ex.width+dr.X] = dr.Cell
+		dirty = dirty.Union(image.Rect(dr.X, dr.Y, dr.X+1, dr.Y+1))
+	}
+	return dirty
+}
+
+// Render returns an image of the given rectangle of screen cells.
+func (ex *imageExporter) Render(cells image.Rectangle) *image.Paletted {
+	img := image.NewPaletted(image.Rect(cells.Min.X*ex.cw, cells.Min.Y*ex.ch, cells.Max.X*ex.cw, cells.Max.Y*ex.ch), ex.palette)
+	for y := cells.Min.Y; y < cells.Max.Y; y++ {
+		for x := cells.Min.X; x < cells.Max.X; x++ {
+			c := ex.screen[y*ex.width+x]
+			if c.R == 0 {
+				c.R = ' '
+			}
+			m, err := ex.mask(c.R, c.InMap && ex.tiles)
+			if err != nil {
+				m, _ = ex.mask(' ', false)
+			}
+			fg := uint8(ex.ui.Color16(c.Fg))
+			bg := uint8(ex.ui.Color16(c.Bg))
+			for j := 0; j < ex.ch; j++ {
+				off := img.PixOffset(x*ex.cw, y*ex.ch+j)
+				for i := 0; i < ex.cw; i++ {
+					if m[j*ex.cw+i] {
+						img.Pix[off+i] = fg
+					} else {
+						img.Pix[off+i] = bg
+					}
+				}
+			}
+		}
+	}
+	return img
+}
+
+func (ex *imageExporter) inRange(turn int) bool {
+	return turn >= ex.from && (ex.to < 0 || turn <= ex.to)
+}
+
+// WritePNG writes the screen as it is at the end of the turn range.
+func (ex *imageExporter) WritePNG(w io.Writer, frames []drawFrame) error {
+	found := frames == nil
+	for _, df := range frames {
+		if ex.to >= 0 && df.Turn > ex.to {
+			break
+		}
+		ex.Apply(df)
+		if ex.inRange(df.Turn) {
+			found = true
+		}
+	}
+	if !found {
+		return errors.New("no frames in the turn range")
+	}
+	return png.Encode(w, ex.Render(ex.Bounds()))
+}
+
+// WriteGIF writes an animation of the frames in the turn range, sampled at
+// the exporter's frame rate. Pauses are shortened as in the replay viewer,
+// and each image only covers the cells that changed.
+func (ex *imageExporter) WriteGIF(w io.Writer, frames []drawFrame) error {
+	anim := &gif.GIF{Config: image.Config{ColorModel: ex.palette, Width: ex.width * ex.cw, Height: ex.height * ex.ch}}
+	step := time.Second / time.Duration(ex.fps)
+	delay := 100 / ex.fps
+	dirty := ex.Bounds()
+	emit := func() {
+		if dirty.Empty() {
+			anim.Delay[len(anim.Delay)-1] += delay
+			return
+		}
+		anim.Image = append(anim.Image, ex.Render(dirty))
+		anim.Delay = append(anim.Delay, delay)
+		anim.Disposal = append(anim.Disposal, gif.DisposalNone)
+		dirty = image.Rectangle{}
+	}
+	var t, clock time.Duration
+	started := false
+	for i, df := range frames {
+		if i > 0 {
+			d := df.Time.Sub(frames[i-1].Time)
+			if d < 0 {
+				d = 0
+			}
+			if d > 2*time.Second {
+				d = 2 * time.Second
+			}
+			t += d
+		}
+		if ex.to >= 0 && df.Turn > ex.to {
+			break
+		}
+		if ex.inRange(df.Turn) {
+			if !started {
+				started = true
+				clock = t
+			}
+			for clock < t {
+				emit()
+				clock += step
+			}
+		}
+		dirty = dirty.Union(ex.Apply(df))
+	}
+	if !started {
+		return errors.New("no frames in the turn range")
+	}
+	emit()
+	// hold the last image a little
+	anim.Delay[len(anim.Delay)-1] += 200
+	return gif.EncodeAll(w, anim)
+}
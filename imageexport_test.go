@@ -0,0 +1,103 @@
+// +build !js
+
+package main
+
+import (
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseTurnRange(t *testing.T) {
+	type tableTest struct {
+		s    string
+		from int
+		to   int
+		err  bool
+	}
+	table := []tableTest{
+		{"", 0, -1, false},
+		{"  ", 0, -1, false},
+		{"100-200", 100, 200, false},
+		{"100-", 100, -1, false},
+		{"-200", 0, 200, false},
+		{"150", 150, 150, false},
+		{" 10-20 ", 10, 20, false},
+		{"200-100", 0, 0, true},
+		{"a-100", 0, 0, true},
+		{"100-b", 0, 0, true},
+		{"abc", 0, 0, true},
+		{"1-2-3", 0, 0, true},
+	}
+	for _, test := range table {
+		from, to, err := ParseTurnRange(test.s)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expected error for %q", test.s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", test.s, err)
+			continue
+		}
+		if from != test.from || to != test.to {
+			t.Errorf("Bad range for %q: %d-%d", test.s, from, to)
+		}
+	}
+}
+
+func TestLoadPalette(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boohu-palette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	colors := ""
+	for i := 0; i < 16; i++ {
+		colors += "#0000ff\n"
+	}
+	type tableTest struct {
+		content string
+		err     bool
+	}
+	table := []tableTest{
+		{colors, false},
+		{"\n  " + colors + "\n\n", false},
+		{colors[1:], false}, // without leading #
+		{colors + "#000000\n", true},
+		{colors[8:], true},
+		{"#0000fg\n" + colors[8:], true},
+		{"#00ff\n" + colors[8:], true},
+	}
+	for i, test := range table {
+		file := filepath.Join(dir, "palette")
+		err := ioutil.WriteFile(file, []byte(test.content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := LoadPalette(file)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expected error for palette %d", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for palette %d: %v", i, err)
+			continue
+		}
+		if len(p) != 16 || p[15] != (color.RGBA{0, 0, 255, 255}) {
+			t.Errorf("Bad colors for palette %d: %v", i, p)
+		}
+	}
+	if _, err := LoadPalette(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected error for missing palette file")
+	}
+	p, err := LoadPalette("")
+	if err != nil || len(p) != 16 {
+		t.Errorf("Bad default palette: %v %v", p, err)
+	}
+}
@@ -1,5 +1,3 @@
-// +build js tk
-
 // font used for letters: source code pro
 
 package main
@@ -0,0 +1,72 @@
+package main
+
+func (mk monsterKind) Invisible() bool {
+	switch mk {
+	case MonsMirrorSpecter:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *monster) Invisible() bool {
+	return m.Kind.Invisible() || m.HasEffect(EffInvisibility)
+}
+
+// Visible reports whether the player can currently see the monster.
+func (m *monster) Visible(g *game) bool {
+	return m.Exists() && g.Player.LOS[m.Pos] && !m.Invisible()
+}
+
+func (m *monster) SeenName(g *game, capital bool) string {
+	if m.Visible(g) {
+		return m.Kind.Definite(capital)
+	}
+	if capital {
+		return "Something"
+	}
+	return "something"
+}
+
+func (m *monster) SeenIndefinite(g *game, capital bool) string {
+	if m.Visible(g) {
+		return m.Kind.Indefinite(capital)
+	}
+	if capital {
+		return "Something"
+	}
+	return "something"
+}
+
+// Disturbance gives indirect cues about an invisible monster that moved
+// to pos.
+func (g *game) Disturbance(m *monster, pos position) {
+	if !m.Invisible() || !g.Player.LOS[pos] {
+		return
+	}
+	if cld, ok := g.Clouds[pos]; ok {
+		g.Printf("You see %s swirl.", cld)
+	} else if _, ok := g.Fungus[pos]; ok {
+		g.Print("You hear foliage rustling.")
+	} else {
+		return
+	}
+	g.MarkNoise(pos)
+	g.StopAuto()
+}
+
+func (g *game) UnseenAttack(m *monster) {
+	if m.Visible(g) {
+		return
+	}
+	g.MarkNoise(m.Pos)
+	g.StopAuto()
+}
+
+// MarkNoise records a noise cue at pos, shown to the player on next turn.
+func (g *game) MarkNoise(pos position) {
+	if g.NoiseCues == nil {
+		g.NoiseCues = map[position]bool{}
+	}
+	g.NoiseCues[pos] = true
+}
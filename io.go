@@ -3,6 +3,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -100,6 +101,96 @@ func (g *game) Load() (bool, error) {
 	return true, nil
 }
 
+func (g *game) WriteBones() error {
+	dataDir, err := g.DataDir()
+	if err != nil {
+		return err
+	}
+	data, err := g.NewBones().BonesSave()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dataDir, "bones"), data, 0644)
+}
+
+// LoadBones loads the bones of a previous character, if any, and removes
+// the bones file, so that a ghost is met only once.
+func (g *game) LoadBones() error {
+	if DisableBones {
+		return nil
+	}
+	dataDir, err := g.DataDir()
+	if err != nil {
+		return err
+	}
+	bonesFile := filepath.Join(dataDir, "bones")
+	_, err = os.Stat(bonesFile)
+	if err != nil {
+		// no bones file
+		return nil
+	}
+	data, err := ioutil.ReadFile(bonesFile)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(bonesFile)
+	if err != nil {
+		return err
+	}
+	b, err := g.DecodeBones(data)
+	if err != nil {
+		return err
+	}
+	if b.Version != Version {
+		return nil
+	}
+	g.Bones = b
+	return nil
+}
+
+// LoadScores returns the score table of finished games, which is empty if no
+// game was recorded yet.
+func (g *game) LoadScores() (*scoreTable, error) {
+	dataDir, err := g.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	scoresFile := filepath.Join(dataDir, "scores")
+	_, err = os.Stat(scoresFile)
+	if err != nil {
+		// no scores file
+		return &scoreTable{}, nil
+	}
+	data, err := ioutil.ReadFile(scoresFile)
+	if err != nil {
+		return nil, err
+	}
+	return g.DecodeScores(data)
+}
+
+// RecordScore adds the outcome of the current game to the score table.
+func (g *game) RecordScore(win bool) error {
+	st, err := g.LoadScores()
+	if err != nil {
+		return err
+	}
+	st.Entries = append(st.Entries, g.NewScoreEntry(win))
+	data, err := st.ScoresSave()
+	if err != nil {
+		return err
+	}
+	dataDir, err := g.DataDir()
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(dataDir, "scores"), data, 0644)
+	if err != nil {
+		return err
+	}
+	g.scores = st
+	return nil
+}
+
 func (g *game) SaveConfig() error {
 	dataDir, err := g.DataDir()
 	if err != nil {
@@ -188,6 +279,7 @@ func (g *game) LoadReplay(file string) error {
 	if file != "_" {
 		replayFile = file
 	}
+	g.replayFile = replayFile
 	_, err = os.Stat(replayFile)
 	if err != nil {
 		// no save file, new game
@@ -205,6 +297,20 @@ func (g *game) LoadReplay(file string) error {
 	return nil
 }
 
+func (g *game) LoadBookmarks() ([]byte, error) {
+	if g.replayFile == "" {
+		return nil, errors.New("no replay file")
+	}
+	return ioutil.ReadFile(g.replayFile + ".bookmarks")
+}
+
+func (g *game) SaveBookmarks(data []byte) error {
+	if g.replayFile == "" {
+		return errors.New("no replay file")
+	}
+	return ioutil.WriteFile(g.replayFile+".bookmarks", data, 0644)
+}
+
 func (g *game) WriteDump() error {
 	dataDir, err := g.DataDir()
 	if err != nil {
@@ -214,9 +320,28 @@ func (g *game) WriteDump() error {
 	if err != nil {
 		return fmt.Errorf("writing game statistics: %v", err)
 	}
+	if HTMLDump {
+		err = ioutil.WriteFile(filepath.Join(dataDir, "dump.html"), []byte(g.ui.HTMLDump()), 0644)
+		if err != nil {
+			return fmt.Errorf("writing HTML game statistics: %v", err)
+		}
+	}
 	err = g.SaveReplay()
 	if err != nil {
 		return fmt.Errorf("writing replay: %v", err)
 	}
 	return nil
 }
+
+func (g *game) WriteLog() (string, error) {
+	dataDir, err := g.DataDir()
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(dataDir, "log")
+	err = ioutil.WriteFile(file, []byte(g.LogText()), 0644)
+	if err != nil {
+		return "", fmt.Errorf("writing message log: %v", err)
+	}
+	return file, nil
+}
@@ -123,7 +123,7 @@ func (p potion) Desc() (text string) {
 	case ShadowsPotion:
 		text = "reduces your line of sight range to 1. Because monsters only can see you if you see them, this makes it easier to get out of sight of monsters so that they eventually stop chasing you."
 	case TormentPotion:
-		text = "halves HP of every creature in sight, including the player, and destroys visible walls. Extremely noisy. It can burn foliage and doors."
+		text = "halves HP of every creature in sight, including the player, and destroys visible walls. Surviving monsters are afraid for a while. Extremely noisy. It can burn foliage and doors."
 	case AccuracyPotion:
 		text = "makes you never miss for a few turns."
 	case DreamPotion:
@@ -146,7 +146,7 @@ func (p potion) Use(g *game, ev event) error {
 		// should not happen
 		return errors.New("no such consumable: " + p.String())
 	}
-	if g.Player.HasStatus(StatusNausea) {
+	if g.Player.HasEffect(EffNausea) {
 		return errors.New("You cannot drink potions while sick.")
 	}
 	var err error
@@ -195,30 +195,25 @@ func (p potion) Use(g *game, ev event) error {
 }
 
 func (g *game) QuaffTeleportation(ev event) error {
-	if g.Player.HasStatus(StatusLignification) {
+	if g.Player.HasEffect(EffLignification) {
 		return errors.New("You cannot teleport while lignified.")
 	}
-	if g.Player.HasStatus(StatusTele) {
+	if g.Player.HasEffect(EffTele) {
 		return errors.New("You already quaffed a potion of teleportation.")
 	}
-	delay := 20 + RandInt(30)
-	g.Player.Statuses[StatusTele] = 1
-	g.PushEvent(&simpleEvent{ERank: ev.Rank() + delay, EAction: Teleportation})
+	g.PlayerAddEffect(EffTele, EffTele.Duration())
 	g.Printf("You quaff the %s. You feel unstable.", TeleportationPotion)
 	return nil
 }
 
 func (g *game) QuaffBerserk(ev event) error {
-	if g.Player.HasStatus(StatusExhausted) {
+	if g.Player.HasEffect(EffExhausted) {
 		return errors.New("You are too exhausted to berserk.")
 	}
-	if g.Player.HasStatus(StatusBerserk) {
+	if g.Player.HasEffect(EffBerserk) {
 		return errors.New("You are already berserk.")
 	}
-	g.Player.Statuses[StatusBerserk] = 1
-	end := ev.Rank() + 65 + RandInt(20)
-	g.PushEvent(&simpleEvent{ERank: end, EAction: BerserkEnd})
-	g.Player.Expire[StatusBerserk] = end
+	g.PlayerAddEffect(EffBerserk, EffBerserk.Duration())
 	g.Printf("You quaff the %s. You feel a sudden urge to kill things.", BerserkPotion)
 	g.Player.HP += 10
 	return nil
@@ -246,7 +241,7 @@ func (g *game) QuaffMagic(ev event) error {
 
 func (g *game) QuaffDescent(ev event) error {
 	// why not?
-	//if g.Player.HasStatus(StatusLignification) {
+	//if g.Player.HasEffect(EffLignification) {
 	//return errors.New("You cannot descend while lignified.")
 	//}
 	if g.Depth >= MaxDepth {
@@ -263,53 +258,40 @@ func (g *game) QuaffDescent(ev event) error {
 }
 
 func (g *game) QuaffSwiftness(ev event) error {
-	g.Player.Statuses[StatusSwift]++
-	end := ev.Rank() + 85 + RandInt(20)
-	g.PushEvent(&simpleEvent{ERank: end, EAction: HasteEnd})
-	g.Player.Expire[StatusSwift] = end
-	g.Player.Statuses[StatusAgile]++
-	g.PushEvent(&simpleEvent{ERank: end, EAction: EvasionEnd})
-	g.Player.Expire[StatusAgile] = end
+	d := EffAgile.Duration()
+	g.PlayerAddEffect(EffSwift, d)
+	g.PlayerAddEffect(EffAgile, d)
 	g.Printf("You quaff the %s. You feel speedy and agile.", SwiftnessPotion)
 	return nil
 }
 
 func (g *game) QuaffDigPotion(ev event) error {
-	g.Player.Statuses[StatusDig] = 1
-	end := ev.Rank() + 75 + RandInt(20)
-	g.PushEvent(&simpleEvent{ERank: end, EAction: DigEnd})
-	g.Player.Expire[StatusDig] = end
+	g.PlayerAddEffect(EffDig, EffDig.Duration())
 	g.Printf("You quaff the %s. You feel like an earth dragon.", DigPotion)
 	return nil
 }
 
 func (g *game) QuaffSwapPotion(ev event) error {
-	if g.Player.HasStatus(StatusLignification) {
+	if g.Player.HasEffect(EffLignification) {
 		return errors.New("You cannot drink this potion while lignified.")
 	}
-	g.Player.Statuses[StatusSwap] = 1
-	end := ev.Rank() + 130 + RandInt(41)
-	g.PushEvent(&simpleEvent{ERank: end, EAction: SwapEnd})
-	g.Player.Expire[StatusSwap] = end
+	g.PlayerAddEffect(EffSwap, EffSwap.Duration())
 	g.Printf("You quaff the %s. You feel light-footed.", SwapPotion)
 	return nil
 }
 
 func (g *game) QuaffShadowsPotion(ev event) error {
-	if g.Player.HasStatus(StatusShadows) {
+	if g.Player.HasEffect(EffShadows) {
 		return errors.New("You are already surrounded by shadows.")
 	}
-	g.Player.Statuses[StatusShadows] = 1
-	end := ev.Rank() + 130 + RandInt(41)
-	g.PushEvent(&simpleEvent{ERank: end, EAction: ShadowsEnd})
-	g.Player.Expire[StatusShadows] = end
+	g.PlayerAddEffect(EffShadows, EffShadows.Duration())
 	g.Printf("You quaff the %s. You feel surrounded by shadows.", ShadowsPotion)
 	g.ComputeLOS()
 	return nil
 }
 
 func (g *game) QuaffLignification(ev event) error {
-	if g.Player.HasStatus(StatusLignification) {
+	if g.Player.HasEffect(EffLignification) {
 		return errors.New("You are already lignified.")
 	}
 	g.EnterLignification(ev)
@@ -362,14 +344,16 @@ func (g *game) QuaffTormentPotion(ev event) error {
 		}
 		g.ExplosionAt(ev, pos)
 	}
+	for _, mons := range g.Monsters {
+		if mons.Exists() && g.Player.LOS[mons.Pos] {
+			mons.AddEffect(g, EffFear, EffFear.Duration())
+		}
+	}
 	return nil
 }
 
 func (g *game) QuaffAccuracyPotion(ev event) error {
-	g.Player.Statuses[StatusAccurate]++
-	end := ev.Rank() + 85 + RandInt(20)
-	g.PushEvent(&simpleEvent{ERank: end, EAction: AccurateEnd})
-	g.Player.Expire[StatusAccurate] = end
+	g.PlayerAddEffect(EffAccurate, EffAccurate.Duration())
 	g.Printf("You quaff the %s. You feel accurate.", SwiftnessPotion)
 	return nil
 }
@@ -399,7 +383,7 @@ func (g *game) QuaffWallPotion(ev event) error {
 }
 
 func (g *game) QuaffCBlinkPotion(ev event) error {
-	if g.Player.HasStatus(StatusLignification) {
+	if g.Player.HasEffect(EffLignification) {
 		return errors.New("You cannot blink while lignified.")
 	}
 	if err := g.ui.ChooseTarget(&chooser{free: true}); err != nil {
@@ -520,7 +504,7 @@ func (g *game) ThrowConfusingDart(ev event) error {
 	}
 	mons := g.MonsterAt(g.Player.Target)
 	bonus := 0
-	if g.Player.HasStatus(StatusBerserk) {
+	if g.Player.HasEffect(EffBerserk) {
 		bonus += RandInt(5)
 	}
 	if g.Player.Aptitudes[AptStrong] {
@@ -579,6 +563,7 @@ func (g *game) ThrowExplosiveMagara(ev event) error {
 	for _, pos := range append(neighbors, g.Player.Target) {
 		g.ExplosionAt(ev, pos)
 	}
+	g.ExplosionKnockback(g.Player.Target, ev)
 
 	ev.Renew(g, 7)
 	return nil
@@ -615,8 +600,7 @@ func (g *game) ThrowSlowingMagara(ev event) error {
 		if !mons.Exists() {
 			continue
 		}
-		mons.Statuses[MonsSlow]++
-		g.PushEvent(&monsterEvent{ERank: g.Ev.Rank() + 130 + RandInt(40), NMons: mons.Index, EAction: MonsSlowEnd})
+		mons.AddEffect(g, EffSlow, 130+RandInt(40))
 	}
 
 	ev.Renew(g, 7)
@@ -931,11 +915,11 @@ func (wp weapon) Desc() string {
 	case Axe:
 		text = "An axe is a one-handed weapon that can hit at once any foes adjacent to you, dealing extra damage in the open."
 	case BattleAxe:
-		text = "A battle axe is a big two-handed weapon that can hit at once any foes adjacent to you, dealing extra damage in the open."
+		text = "A battle axe is a big two-handed weapon that can hit at once any foes adjacent to you, dealing extra damage in the open. It can knock foes back."
 	case Spear:
 		text = "A spear is a one-handed weapon that can hit two opponents in a row at once. Useful in corridors."
 	case Halberd:
-		text = "An halberd is a big two-handed weapon that can hit two opponents in a row at once. Useful in corridors."
+		text = "An halberd is a big two-handed weapon that can hit two opponents in a row at once. Useful in corridors. It can knock foes back."
 	case AssassinSabre:
 		text = "The assassin sabre is a one-handed weapon. It is more accurate against injured opponents."
 	case DancingRapier:
@@ -61,8 +61,12 @@ func newGame(ui *gameui) {
 	}
 	load, err = g.Load()
 	if !load {
+		g.Opts.Difficulty = ui.SelectDifficulty()
+		g.Opts.Background = ui.SelectBackground()
 		g.InitLevel()
 	} else if err != nil {
+		g.Opts.Difficulty = ui.SelectDifficulty()
+		g.Opts.Background = ui.SelectBackground()
 		g.InitLevel()
 		g.Printf("Error loading saved game… starting new game. (%v)", err)
 	} else {
@@ -121,6 +125,7 @@ type gameui struct {
 	mousepos  position
 	menuHover menu
 	itemHover int
+	textInput bool // set while reading a line of text
 }
 
 func (ui *gameui) InitElements() error {
@@ -198,6 +203,50 @@ func (g *game) Save() error {
 	return nil
 }
 
+func (g *game) WriteBones() error {
+	// not available in the browser version
+	return nil
+}
+
+func (g *game) LoadBones() error {
+	return nil
+}
+
+func (g *game) LoadScores() (*scoreTable, error) {
+	storage := js.Global().Get("localStorage")
+	if storage.Type() != js.TypeObject {
+		return nil, errors.New("localStorage not found")
+	}
+	scores := storage.Call("getItem", "boohuscores")
+	if scores.Type() != js.TypeString || runtime.GOARCH != "wasm" {
+		return &scoreTable{}, nil
+	}
+	s, err := base64.StdEncoding.DecodeString(scores.String())
+	if err != nil {
+		return nil, err
+	}
+	return g.DecodeScores(s)
+}
+
+func (g *game) RecordScore(win bool) error {
+	if runtime.GOARCH != "wasm" {
+		return nil
+	}
+	st, err := g.LoadScores()
+	if err != nil {
+		return err
+	}
+	st.Entries = append(st.Entries, g.NewScoreEntry(win))
+	data, err := st.ScoresSave()
+	if err != nil {
+		return err
+	}
+	storage := js.Global().Get("localStorage")
+	storage.Call("setItem", "boohuscores", base64.StdEncoding.EncodeToString(data))
+	g.scores = st
+	return nil
+}
+
 func (g *game) SaveConfig() error {
 	if runtime.GOARCH != "wasm" {
 		return nil
@@ -318,6 +367,27 @@ func (g *game) LoadReplay() error {
 	return nil
 }
 
+func (g *game) LoadBookmarks() ([]byte, error) {
+	storage := js.Global().Get("localStorage")
+	if storage.Type() != js.TypeObject {
+		return nil, errors.New("localStorage not found")
+	}
+	bms := storage.Call("getItem", "boohureplaybookmarks")
+	if bms.Type() != js.TypeString {
+		return nil, errors.New("no bookmarks")
+	}
+	return []byte(bms.String()), nil
+}
+
+func (g *game) SaveBookmarks(data []byte) error {
+	storage := js.Global().Get("localStorage")
+	if storage.Type() != js.TypeObject {
+		return errors.New("localStorage not found")
+	}
+	storage.Call("setItem", "boohureplaybookmarks", string(data))
+	return nil
+}
+
 func (g *game) WriteDump() error {
 	pre := js.Global().Get("document").Call("getElementById", "dump")
 	pre.Set("innerHTML", g.Dump())
@@ -328,6 +398,10 @@ func (g *game) WriteDump() error {
 	return nil
 }
 
+func (g *game) WriteLog() (string, error) {
+	return "", errors.New("Writing the log is not available in the browser.")
+}
+
 // End of io compatibility functions
 
 func (ui *gameui) Init() error {
@@ -463,12 +537,22 @@ func (ui *gameui) PollEvent() (in uiInput) {
 	case "Escape", "Space":
 		in.key = "\x1b"
 	case "Enter", "\r", "\n":
-		in.key = "."
+		if ui.textInput {
+			in.key = "Enter"
+		} else {
+			in.key = "."
+		}
 	case "ArrowLeft":
 		in.key = "4"
 	case "ArrowRight":
 		in.key = "6"
-	case "ArrowUp", "BackSpace":
+	case "BackSpace", "Backspace":
+		if ui.textInput {
+			in.key = "BackSpace"
+		} else {
+			in.key = "8"
+		}
+	case "ArrowUp":
 		in.key = "8"
 	case "ArrowDown":
 		in.key = "2"
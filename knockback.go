@@ -0,0 +1,262 @@
+package main
+
+import "errors"
+
+// Heavy reports whether the monster is too big or too rooted to be knocked
+// back.
+func (mk monsterKind) Heavy() bool {
+	switch mk {
+	case MonsOgre, MonsCyclop, MonsBrizzia, MonsHydra, MonsEarthDragon, MonsSatowalgaPlant, MonsTreeMushroom, MonsEarthShaper:
+		return true
+	default:
+		return false
+	}
+}
+
+func (wp weapon) Knockback() bool {
+	switch wp {
+	case BattleAxe, Halberd:
+		return true
+	default:
+		return false
+	}
+}
+
+func (g *game) KnockbackObstacle(pos position) bool {
+	return !pos.valid() || g.Dungeon.Cell(pos).T == WallCell || g.ClosedDoor(pos)
+}
+
+func (g *game) ObstacleName(pos position) string {
+	if pos.valid() && g.ClosedDoor(pos) {
+		return "the door"
+	}
+	return "the wall"
+}
+
+// KnockbackMonster pushes a monster up to dist cells in direction dir. The
+// monster stops when it collides with a wall, a door or another creature,
+// and takes damage from the impact. It returns true if the monster moved.
+func (g *game) KnockbackMonster(mons *monster, dir direction, dist int, ev event) bool {
+	if !mons.Exists() || mons.Kind.Heavy() || mons.HasEffect(EffLignification) {
+		return false
+	}
+	pos := mons.Pos
+	path := []position{}
+	wall := false
+	var other *monster
+	for i := 0; i < dist; i++ {
+		npos := pos.To(dir)
+		if g.KnockbackObstacle(npos) {
+			wall = true
+			break
+		}
+		if npos == g.Player.Pos {
+			break
+		}
+		if m := g.MonsterAt(npos); m.Exists() {
+			other = m
+			break
+		}
+		pos = npos
+		path = append(path, pos)
+	}
+	moved := pos != mons.Pos
+	if moved {
+		seen := mons.Visible(g)
+		mons.MoveTo(g, pos)
+		if seen || mons.Visible(g) {
+			g.ui.MonsterProjectileAnimation(path, mons.Kind.Letter(), ColorFgMonster)
+			g.Printf("%s is knocked back.", mons.Kind.Definite(true))
+		}
+	}
+	switch {
+	case wall:
+		dmg := 2 + RandInt(3)
+		if mons.Visible(g) {
+			g.PrintfStyled("%s slams into %s (%d dmg).", logPlayerHit, mons.Kind.Definite(true), g.ObstacleName(mons.Pos.To(dir)), dmg)
+		}
+		g.ImpactMonster(mons, dmg, ev)
+	case other != nil:
+		dmg := 1 + RandInt(3)
+		odmg := 1 + RandInt(3)
+		if mons.Visible(g) || other.Visible(g) {
+			g.PrintfStyled("%s collides with %s (%d and %d dmg).", logPlayerHit, mons.SeenName(g, true), other.SeenName(g, false), dmg, odmg)
+		}
+		g.ImpactMonster(mons, dmg, ev)
+		g.ImpactMonster(other, odmg, ev)
+	}
+	if moved && mons.Exists() {
+		g.KnockbackHazards(mons.Pos, ev)
+		if mons.Exists() {
+			g.HandleStone(mons)
+		}
+	}
+	return moved
+}
+
+func (g *game) ImpactMonster(mons *monster, dmg int, ev event) {
+	if !mons.Exists() {
+		return
+	}
+	mons.HP -= dmg
+	if mons.HP > 0 {
+		mons.MakeAwareIfHurt(g)
+		return
+	}
+	if g.Player.LOS[mons.Pos] {
+		g.PrintfStyled("%s is killed by the impact.", logPlayerHit, mons.Kind.Definite(true))
+	}
+	g.HandleKill(mons, ev)
+}
+
+// KnockbackPlayer pushes the player up to dist cells in direction dir, in
+// the same way as KnockbackMonster.
+func (g *game) KnockbackPlayer(dir direction, dist int, ev event) bool {
+	if g.Player.HasEffect(EffLignification) {
+		return false
+	}
+	pos := g.Player.Pos
+	path := []position{}
+	wall := false
+	var other *monster
+	for i := 0; i < dist; i++ {
+		npos := pos.To(dir)
+		if g.KnockbackObstacle(npos) {
+			wall = true
+			break
+		}
+		if m := g.MonsterAt(npos); m.Exists() {
+			other = m
+			break
+		}
+		pos = npos
+		path = append(path, pos)
+	}
+	moved := pos != g.Player.Pos
+	if moved {
+		g.PlacePlayerAt(pos)
+		g.ui.MonsterProjectileAnimation(path, '@', ColorFgPlayer)
+		g.Print("You are knocked back.")
+	}
+	switch {
+	case wall:
+		dmg := 1 + RandInt(4)
+		g.PrintfStyled("You slam into %s (%d dmg).", logMonsterHit, g.ObstacleName(g.Player.Pos.To(dir)), dmg)
+		g.ImpactPlayer(dmg)
+	case other != nil:
+		dmg := 1 + RandInt(3)
+		odmg := 1 + RandInt(3)
+		g.PrintfStyled("You collide with %s (%d and %d dmg).", logMonsterHit, other.SeenName(g, false), dmg, odmg)
+		g.ImpactPlayer(dmg)
+		g.ImpactMonster(other, odmg, ev)
+	}
+	if moved || wall || other != nil {
+		g.StopAuto()
+	}
+	if moved && g.Player.HP > 0 {
+		g.KnockbackHazards(g.Player.Pos, ev)
+	}
+	return moved
+}
+
+func (g *game) ImpactPlayer(dmg int) {
+	if dmg >= g.Player.HP {
+		// collisions alone are never deadly
+		dmg = g.Player.HP - 1
+		if dmg <= 0 {
+			return
+		}
+	}
+	g.DamagePlayer(dmg)
+}
+
+// KnockbackHazards applies the effects of the clouds at pos to the creature
+// that has just been pushed there.
+func (g *game) KnockbackHazards(pos position, ev event) {
+	cld, ok := g.Clouds[pos]
+	if !ok {
+		return
+	}
+	switch {
+	case cld == CloudFire:
+		g.BurnCreature(pos, ev)
+	case cld.Gas():
+		g.GasCreature(pos, cld, ev)
+	}
+}
+
+// ExplosionKnockback may push creatures adjacent to an explosion center one
+// cell away.
+func (g *game) ExplosionKnockback(center position, ev event) {
+	for _, pos := range center.ValidNeighbors() {
+		if RandInt(2) == 0 {
+			continue
+		}
+		dir := pos.Dir(center)
+		if mons := g.MonsterAt(pos); mons.Exists() {
+			g.KnockbackMonster(mons, dir, 1, ev)
+		} else if pos == g.Player.Pos && g.Player.HP > 0 {
+			g.KnockbackPlayer(dir, 1, ev)
+		}
+	}
+}
+
+// WeaponKnockback may push an adjacent monster hit by a knockback weapon.
+func (g *game) WeaponKnockback(mons *monster, ev event) {
+	if !g.Player.Weapon.Knockback() || !mons.Exists() || mons.Pos.Distance(g.Player.Pos) != 1 || RandInt(3) != 0 {
+		return
+	}
+	g.KnockbackMonster(mons, mons.Pos.Dir(g.Player.Pos), 1, ev)
+}
+
+func (g *game) ShoveTarget() (*monster, error) {
+	var target *monster
+	count := 0
+	for _, pos := range g.Player.Pos.ValidNeighbors() {
+		mons := g.MonsterAt(pos)
+		if mons.Visible(g) {
+			target = mons
+			count++
+		}
+	}
+	switch count {
+	case 0:
+		return nil, errors.New("There is no monster next to you.")
+	case 1:
+		return target, nil
+	}
+	if err := g.ui.ChooseTarget(&chooser{adjacent: true}); err != nil {
+		return nil, err
+	}
+	mons := g.MonsterAt(g.Player.Target)
+	if !mons.Visible(g) {
+		return nil, errors.New("You must target a monster.")
+	}
+	return mons, nil
+}
+
+func (g *game) Shove(ev event) error {
+	if g.Player.HasEffect(EffLignification) {
+		return errors.New("You cannot shove while lignified.")
+	}
+	mons, err := g.ShoveTarget()
+	if err != nil {
+		return err
+	}
+	if mons.Kind.Heavy() {
+		return errors.New("This monster is too heavy to be shoved.")
+	}
+	if mons.HasEffect(EffLignification) {
+		return errors.New("This monster is rooted to the ground.")
+	}
+	dist := 1
+	if g.Player.HasEffect(EffBerserk) {
+		dist = 2
+	}
+	g.Printf("You shove %s.", mons.Kind.Definite(false))
+	g.MakeNoise(BaseHitNoise, mons.Pos)
+	g.KnockbackMonster(mons, mons.Pos.Dir(g.Player.Pos), dist, ev)
+	mons.MakeHuntIfHurt(g)
+	ev.Renew(g, 10)
+	return nil
+}
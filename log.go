@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
 
 type logStyle int
 
@@ -20,6 +24,8 @@ type logEntry struct {
 	Tick  bool
 	Style logStyle
 	Dups  int
+	Turn  int
+	Depth int
 }
 
 func (e logEntry) String() string {
@@ -29,6 +35,97 @@ func (e logEntry) String() string {
 	return e.Text
 }
 
+// Stamp returns the depth and turn at which the entry was logged.
+func (e logEntry) Stamp() string {
+	return fmt.Sprintf("D%-2d T%-5d", e.Depth, e.Turn)
+}
+
+type logFilter int
+
+const (
+	LogFilterAll logFilter = iota
+	LogFilterCritic
+	LogFilterPlayerHit
+	LogFilterMonsterHit
+	LogFilterSpecial
+)
+
+const NumLogFilters = int(LogFilterSpecial) + 1
+
+func (f logFilter) String() (text string) {
+	switch f {
+	case LogFilterAll:
+		text = "all messages"
+	case LogFilterCritic:
+		text = "critical"
+	case LogFilterPlayerHit:
+		text = "player hits"
+	case LogFilterMonsterHit:
+		text = "monster hits"
+	case LogFilterSpecial:
+		text = "special"
+	}
+	return text
+}
+
+func (f logFilter) Match(e logEntry) bool {
+	switch f {
+	case LogFilterCritic:
+		return e.Style == logCritic || e.Style == logError
+	case LogFilterPlayerHit:
+		return e.Style == logPlayerHit
+	case LogFilterMonsterHit:
+		return e.Style == logMonsterHit
+	case LogFilterSpecial:
+		return e.Style == logSpecial
+	default:
+		return true
+	}
+}
+
+// FilteredLog returns the log entries matching the filter and containing
+// the search text, ignoring case.
+func (g *game) FilteredLog(f logFilter, search string) []logEntry {
+	search = strings.ToLower(search)
+	log := []logEntry{}
+	for _, e := range g.Log {
+		if !f.Match(e) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(e.Text), search) {
+			continue
+		}
+		log = append(log, e)
+	}
+	return log
+}
+
+// DepthStart returns the index of the first entry of the depth block
+// containing the entry at index i.
+func DepthStart(log []logEntry, i int) int {
+	for i > 0 && log[i-1].Depth == log[i].Depth {
+		i--
+	}
+	return i
+}
+
+// DepthNext returns the index of the first entry of the depth block
+// following the one containing the entry at index i, or len(log).
+func DepthNext(log []logEntry, i int) int {
+	for i < len(log)-1 && log[i+1].Depth == log[i].Depth {
+		i++
+	}
+	return i + 1
+}
+
+func (g *game) LogText() string {
+	buf := &bytes.Buffer{}
+	for _, e := range g.Log {
+		fmt.Fprintf(buf, "%s %s\n", e.Stamp(), e)
+	}
+	return buf.String()
+}
+
 func (g *game) Print(s string) {
 	e := logEntry{Text: s, Index: g.LogIndex}
 	g.PrintEntry(e)
@@ -53,6 +150,8 @@ func (g *game) PrintEntry(e logEntry) {
 	if e.Index == g.LogNextTick {
 		e.Tick = true
 	}
+	e.Turn = g.Turn / 10
+	e.Depth = g.Depth
 	if !e.Tick && len(g.Log) > 0 {
 		le := g.Log[len(g.Log)-1]
 		if le.Text == e.Text {
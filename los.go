@@ -23,10 +23,10 @@ func (g *game) losCost(pos position) int {
 	if c.T == WallCell {
 		return g.LosRange()
 	}
-	if _, ok := g.Clouds[pos]; ok {
+	if cld, ok := g.Clouds[pos]; ok && cld.Opaque() {
 		return g.LosRange()
 	}
-	if _, ok := g.Doors[pos]; ok {
+	if g.ClosedDoor(pos) {
 		if pos != g.Player.Pos {
 			mons := g.MonsterAt(pos)
 			if !mons.Exists() {
@@ -80,7 +80,7 @@ func (g *game) LosRange() int {
 	if g.Player.Weapon == Frundis {
 		losRange -= 1
 	}
-	if g.Player.HasStatus(StatusShadows) {
+	if g.Player.HasEffect(EffShadows) {
 		losRange = 1
 	}
 	if losRange < 1 {
@@ -119,19 +119,24 @@ func (g *game) ComputeLOS() {
 	}
 	g.Player.LOS = m
 	for _, mons := range g.Monsters {
-		if mons.Exists() && g.Player.LOS[mons.Pos] {
+		if mons.Visible(g) {
 			if mons.Seen {
-				g.StopAuto()
+				if !g.Autoexploring || !g.IgnoredSleeper(mons) {
+					g.StopAuto()
+				}
 				continue
 			}
 			mons.Seen = true
+			g.GhostSeen(mons)
 			g.Printf("You see %s (%v).", mons.Kind.Indefinite(false), mons.State)
 			if mons.Kind.Dangerousness() > 10 {
 				g.StoryPrint(mons.Kind.SeenStoryText())
 			}
 			g.StopAuto()
+			g.AutoFightHalt()
 		}
 	}
+	g.CheckExploreSleepers()
 }
 
 func (g *game) SeePosition(pos position) {
@@ -232,6 +237,10 @@ func (g *game) ComputeRayHighlight(pos position) {
 	}
 }
 
+// PresenceCueDelay is the minimum delay between two messages about a nearby
+// invisible monster, in game time units (a tenth of a turn): 100 is 10 turns.
+const PresenceCueDelay = 100
+
 func (g *game) ComputeNoise() {
 	dij := &noisePath{game: g}
 	rg := g.LosRange() + 2
@@ -244,18 +253,32 @@ func (g *game) ComputeNoise() {
 	nm := Dijkstra(dij, []position{g.Player.Pos}, rg)
 	count := 0
 	noise := map[position]bool{}
+	for pos := range g.NoiseCues {
+		noise[pos] = true
+	}
+	g.NoiseCues = nil
 	rmax := 3
 	if g.Player.Aptitudes[AptHear] {
 		rmax--
 	}
 	for pos := range nm {
-		if g.Player.LOS[pos] {
+		mons := g.MonsterAt(pos)
+		if g.Player.LOS[pos] && !(mons.Exists() && mons.Invisible()) {
 			continue
 		}
-		mons := g.MonsterAt(pos)
 		if mons.Exists() && mons.State != Resting && RandInt(rmax) == 0 {
 			switch mons.Kind {
-			case MonsMirrorSpecter, MonsSatowalgaPlant:
+			case MonsMirrorSpecter:
+				// no footsteps, but a chilling presence nearby
+				if g.Player.LOS[pos] {
+					noise[pos] = true
+					if g.Turn >= g.PresenceCueTurn+PresenceCueDelay {
+						g.PresenceCueTurn = g.Turn
+						g.Print("You feel a chilling presence.")
+						count++
+					}
+				}
+			case MonsSatowalgaPlant:
 				// no footsteps
 			case MonsTinyHarpy, MonsWingedMilfid, MonsGiantBee:
 				noise[pos] = true
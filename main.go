@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"runtime"
+	"time"
 )
 
 func main() {
@@ -22,6 +23,18 @@ func main() {
 	opt256colors := flag.Bool("x", !color8, "use xterm 256-color palette (solarized approximation)")
 	optNoAnim := flag.Bool("n", false, "no animations")
 	optReplay := flag.String("r", "", "path to replay file")
+	optExportCast := flag.String("export-cast", "", "with -r, export the replay to an asciicast v2 file")
+	optCastIdle := flag.Float64("cast-idle", 0, "with -export-cast, shorten pauses to at most this many seconds (0 keeps them)")
+	optExportImage := flag.String("export-image", "", "export the replay given with -r, or the saved game's level, to a PNG or animated GIF file")
+	optImageTiles := flag.Bool("image-tiles", false, "with -export-image, use the tile set for the map")
+	optImageFPS := flag.Int("image-fps", 10, "with -export-image, frame rate of animated GIF files")
+	optImageTurns := flag.String("image-turns", "", "with -export-image, range of turns to export, as in 100-200")
+	optImagePalette := flag.String("image-palette", "", "with -export-image, file with the 16 palette colors (#rrggbb), one per line")
+	optServe := flag.String("serve", "", "stream the game to spectators on a TCP address or a unix:path socket")
+	optWatch := flag.String("watch", "", "watch the game streamed on a TCP address or a unix:path socket")
+	optNoBones := flag.Bool("B", false, "disable bones files (ghosts of previous characters)")
+	optHTMLDump := flag.Bool("html", false, "also write the character dump as an HTML file")
+	optScores := flag.Bool("scores", false, "print the score table of finished games")
 	flag.Parse()
 	if *optSolarized {
 		SolarizedPalette()
@@ -33,6 +46,45 @@ func main() {
 		fmt.Println(Version)
 		os.Exit(0)
 	}
+	if *optScores {
+		g := &game{}
+		st, err := g.LoadScores()
+		if err != nil {
+			log.Printf("boohu: scores: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(st)
+		os.Exit(0)
+	}
+	if *optExportCast != "" {
+		if *optReplay == "" {
+			log.Printf("boohu: -export-cast requires a replay file (-r)\n")
+			os.Exit(1)
+		}
+		err := ExportCast(*optReplay, *optExportCast, time.Duration(*optCastIdle*float64(time.Second)))
+		if err != nil {
+			log.Printf("boohu: export-cast: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *optWatch != "" {
+		err := Watch(*optWatch)
+		if err != nil {
+			log.Printf("boohu: watch: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *optExportImage != "" {
+		opts := imageOptions{Tiles: *optImageTiles, FPS: *optImageFPS, Turns: *optImageTurns, Palette: *optImagePalette}
+		err := ExportImage(*optReplay, *optExportImage, opts)
+		if err != nil {
+			log.Printf("boohu: export-image: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 	if *optReplay != "" {
 		err := Replay(*optReplay)
 		if err != nil {
@@ -47,6 +99,22 @@ func main() {
 	if *optNoAnim {
 		DisableAnimations = true
 	}
+	if *optNoBones {
+		DisableBones = true
+	}
+	if *optHTMLDump {
+		HTMLDump = true
+	}
+
+	if *optServe != "" {
+		srv, err := ServeSpectators(*optServe)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "boohu: serve: %v\n", err)
+			os.Exit(1)
+		}
+		defer srv.Close()
+		FrameListener = srv.Broadcast
+	}
 
 	ui := &gameui{}
 	g := &game{}
@@ -77,9 +145,16 @@ func main() {
 	ui.PostConfig()
 	ui.DrawWelcome()
 	load, err = g.Load()
+	var bonesErr error
 	if !load {
+		g.Opts.Difficulty = ui.SelectDifficulty()
+		g.Opts.Background = ui.SelectBackground()
+		bonesErr = g.LoadBones()
 		g.InitLevel()
 	} else if err != nil {
+		g.Opts.Difficulty = ui.SelectDifficulty()
+		g.Opts.Background = ui.SelectBackground()
+		bonesErr = g.LoadBones()
 		g.InitLevel()
 		g.PrintfStyled("Error: %v", logError, err)
 		g.PrintStyled("Could not load saved game… starting new game.", logError)
@@ -92,6 +167,9 @@ func main() {
 	if cfgreseterr != "" {
 		g.PrintStyled(cfgreseterr, logError)
 	}
+	if bonesErr != nil {
+		g.PrintfStyled("Error loading bones file: %v", logError, bonesErr)
+	}
 	g.ui = ui
 	g.EventLoop()
 }
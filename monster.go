@@ -23,31 +23,6 @@ func (m monsterState) String() string {
 	return st
 }
 
-type monsterStatus int
-
-const (
-	MonsConfused monsterStatus = iota
-	MonsExhausted
-	MonsSlow
-	MonsLignified
-)
-
-const NMonsStatus = int(MonsLignified) + 1
-
-func (st monsterStatus) String() (text string) {
-	switch st {
-	case MonsConfused:
-		text = "confused"
-	case MonsExhausted:
-		text = "exhausted"
-	case MonsSlow:
-		text = "slowed"
-	case MonsLignified:
-		text = "lignified"
-	}
-	return text
-}
-
 type monsterKind int
 
 const (
@@ -77,6 +52,9 @@ const (
 	MonsVampire
 	MonsTreeMushroom
 	MonsMarevorHelith
+	MonsGoblinWarlord
+	MonsEarthShaper
+	MonsGhost
 )
 
 func (mk monsterKind) String() string {
@@ -107,24 +85,6 @@ func (mk monsterKind) Dangerousness() int {
 	return MonsData[mk].dangerousness
 }
 
-func (mk monsterKind) Ranged() bool {
-	switch mk {
-	case MonsLich, MonsCyclop, MonsGoblinWarrior, MonsSatowalgaPlant, MonsMadNixe, MonsVampire, MonsTreeMushroom:
-		return true
-	default:
-		return false
-	}
-}
-
-func (mk monsterKind) Smiting() bool {
-	switch mk {
-	case MonsMirrorSpecter, MonsMindCelmist:
-		return true
-	default:
-		return false
-	}
-}
-
 func (mk monsterKind) Desc() string {
 	return monsDesc[mk]
 }
@@ -133,6 +93,9 @@ func (mk monsterKind) SeenStoryText() (text string) {
 	switch mk {
 	case MonsMarevorHelith:
 		text = "Saw Marevor."
+	case MonsGoblinWarlord, MonsEarthShaper:
+		b, _ := mk.Boss()
+		text = BossesData[b].seen
 	default:
 		text = fmt.Sprintf("Saw %s.", Indefinite(mk.String(), false))
 	}
@@ -141,7 +104,7 @@ func (mk monsterKind) SeenStoryText() (text string) {
 
 func (mk monsterKind) Indefinite(capital bool) (text string) {
 	switch mk {
-	case MonsMarevorHelith:
+	case MonsMarevorHelith, MonsGoblinWarlord, MonsEarthShaper:
 		text = mk.String()
 	default:
 		text = Indefinite(mk.String(), capital)
@@ -151,7 +114,7 @@ func (mk monsterKind) Indefinite(capital bool) (text string) {
 
 func (mk monsterKind) Definite(capital bool) (text string) {
 	switch mk {
-	case MonsMarevorHelith:
+	case MonsMarevorHelith, MonsGoblinWarlord, MonsEarthShaper:
 		text = mk.String()
 	default:
 		if capital {
@@ -165,7 +128,7 @@ func (mk monsterKind) Definite(capital bool) (text string) {
 
 func (mk monsterKind) Living() bool {
 	switch mk {
-	case MonsLich, MonsSkeletonWarrior, MonsMarevorHelith:
+	case MonsLich, MonsSkeletonWarrior, MonsMarevorHelith, MonsEarthShaper, MonsGhost:
 		return false
 	default:
 		return true
@@ -212,6 +175,9 @@ var MonsData = []monsterData{
 	MonsVampire:         {10, 9, 10, 21, 17, 0, 15, 'V', "vampire", 13},
 	MonsTreeMushroom:    {12, 15, 12, 38, 14, 4, 6, 'T', "tree mushroom", 17},
 	MonsMarevorHelith:   {10, 0, 10, 97, 18, 10, 15, 'M', "Marevor Helith", 18},
+	MonsGoblinWarlord:   {10, 12, 10, 55, 16, 5, 13, 'K', "Gorbulg", 15},
+	MonsEarthShaper:     {10, 14, 12, 80, 17, 6, 10, 'A', "Ashkareth", 25},
+	MonsGhost:           {10, 10, 10, 40, 15, 0, 14, 'p', "ghost", 10},
 }
 
 var monsDesc = []string{
@@ -231,9 +197,9 @@ var monsDesc = []string{
 	MonsSpider:          "Spiders are fast moving fragile creatures, whose bite can confuse you.",
 	MonsWingedMilfid:    "Winged milfids are fast moving humanoids that can fly over you and make you swap positions. They tend to be very agressive creatures.",
 	MonsBlinkingFrog:    "Blinking frogs are big frog-like creatures, whose bite can make you blink away.",
-	MonsLich:            "Liches are non-living mages wearing a leather armour. They can throw a bolt of torment at you, halving your HP.",
+	MonsLich:            "Liches are non-living mages wearing a leather armour. They can throw a bolt of torment at you, halving your HP. When wounded, they may turn invisible for a while.",
 	MonsEarthDragon:     "Earth dragons are big and hardy creatures that wander in the Underground. It is said they can be credited for many of the tunnels.",
-	MonsMirrorSpecter:   "Mirror specters are very insubstantial creatures, which can absorb your mana.",
+	MonsMirrorSpecter:   "Mirror specters are very insubstantial creatures, which can absorb your mana. They are invisible, but you may feel their presence nearby.",
 	MonsExplosiveNadre:  "Explosive nadres are very frail creatures that explode upon dying, halving HP of any adjacent creatures and occasionally destroying walls.",
 	MonsSatowalgaPlant:  "Satowalga Plants are immobile bushes that throw acidic projectiles at you, sometimes corroding and confusing you.",
 	MonsMadNixe:         "Mad nixes are magical humanoids that can attract you to them.",
@@ -241,6 +207,9 @@ var monsDesc = []string{
 	MonsVampire:         "Vampires are humanoids that drink blood to survive. Their spitting can cause nausea, impeding the use of potions.",
 	MonsTreeMushroom:    "Tree mushrooms are big clunky slow-moving creatures. They can throw lignifying spores at you.",
 	MonsMarevorHelith:   "Marevor Helith is an ancient undead nakrus very fond of teleporting people away. He is a well-known expert in the field of magaras - items that many people simply call magical objects. His current research focus is monolith creation. Marevor, a repentant necromancer, is now searching for his old disciple Jaixel in the Underground to help him overcome the past.",
+	MonsGoblinWarlord:   "Gorbulg is the warlord of the goblins of the Underground. He waits for challengers in his arena, and calls his guards when the fight goes wrong. He is known for his shameless retreats.",
+	MonsEarthShaper:     "Ashkareth is an ancient nakrus that can shape the stone of the Underground at will. When hurt, she makes the walls around her collapse and rise again, and calls her skeleton guards.",
+	MonsGhost:           "Ghosts are the restless spirits of adventurers who died in the Underground. They fight with the strength they had in life, and still carry their equipment.",
 }
 
 type monsterBand int
@@ -1668,13 +1637,18 @@ type monster struct {
 	HPmax       int
 	HP          int
 	State       monsterState
-	Statuses    [NMonsStatus]int
 	Pos         position
 	Target      position
 	Path        []position // cache
 	Obstructing bool
 	FireReady   bool
 	Seen        bool
+	Effects     effects
+	Mods        [NumChampionMods]bool
+	Items       []collectable
+	Gear        []equipable
+	Rods        []rod
+	Phase       int
 }
 
 func (m *monster) Init() {
@@ -1689,20 +1663,16 @@ func (m *monster) Init() {
 	}
 }
 
-func (m *monster) Status(st monsterStatus) bool {
-	return m.Statuses[st] > 0
-}
-
 func (m *monster) Exists() bool {
 	return m != nil && m.HP > 0
 }
 
 func (m *monster) AlternatePlacement(g *game) *position {
-	if m.Status(MonsLignified) {
+	if m.HasEffect(EffLignification) {
 		return nil
 	}
 	var neighbors []position
-	if m.Status(MonsConfused) {
+	if m.HasEffect(EffConfusion) {
 		neighbors = g.Dungeon.CardinalFreeNeighbors(m.Pos)
 	} else {
 		neighbors = g.Dungeon.FreeNeighbors(m.Pos)
@@ -1742,7 +1712,7 @@ func (m *monster) AlternateConfusedPlacement(g *game) *position {
 
 func (m *monster) SafePlacement(g *game) *position {
 	var neighbors []position
-	if m.Status(MonsConfused) {
+	if m.HasEffect(EffConfusion) {
 		neighbors = g.Dungeon.CardinalFreeNeighbors(m.Pos)
 	} else {
 		neighbors = g.Dungeon.FreeNeighbors(m.Pos)
@@ -1827,15 +1797,19 @@ func (m *monster) TeleportAway(g *game) {
 }
 
 func (m *monster) MoveTo(g *game, pos position) {
-	if !g.Player.LOS[m.Pos] && g.Player.LOS[pos] {
+	if !g.Player.LOS[m.Pos] && g.Player.LOS[pos] && !m.Invisible() {
 		if !m.Seen {
 			m.Seen = true
+			g.GhostSeen(m)
 			g.Printf("%s (%v) comes into view.", m.Kind.Indefinite(true), m.State)
 		}
 		g.StopAuto()
 	}
-	recomputeLOS := g.Player.LOS[m.Pos] && g.Doors[m.Pos] || g.Player.LOS[pos] && g.Doors[pos]
+	recomputeLOS := g.Player.LOS[m.Pos] && g.ClosedDoor(m.Pos) || g.Player.LOS[pos] && g.ClosedDoor(pos)
 	m.PlaceAt(g, pos)
+	g.OpenDoor(pos)
+	g.Disturbance(m, pos)
+	m.PickUp(g)
 	if recomputeLOS {
 		g.ComputeLOS()
 	}
@@ -1872,7 +1846,7 @@ func (m *monster) AttackAction(g *game, ev event) {
 		pos := m.AlternatePlacement(g)
 		if pos != nil {
 			m.MoveTo(g, *pos)
-			ev.Renew(g, m.Kind.MovementDelay())
+			ev.Renew(g, m.MovementDelay())
 			return
 		}
 		fallthrough
@@ -1887,7 +1861,7 @@ func (m *monster) AttackAction(g *game, ev event) {
 			m.HitPlayer(g, ev)
 		}
 		adelay := m.Kind.AttackDelay()
-		if m.Status(MonsSlow) {
+		if m.HasEffect(EffSlow) {
 			adelay += 3
 		}
 		ev.Renew(g, adelay)
@@ -1911,8 +1885,9 @@ func (m *monster) HandleTurn(g *game, ev event) {
 			m.State = Wandering
 		}
 	}
-	movedelay := m.Kind.MovementDelay()
-	if m.Status(MonsSlow) {
+	g.ChampionRegen(m)
+	movedelay := m.MovementDelay()
+	if m.HasEffect(EffSlow) {
 		movedelay += 3
 	}
 	if m.State == Resting {
@@ -1920,13 +1895,16 @@ func (m *monster) HandleTurn(g *game, ev event) {
 		if wander == 0 {
 			m.NaturalAwake(g)
 		}
-		ev.Renew(g, m.Kind.MovementDelay())
+		ev.Renew(g, m.MovementDelay())
+		return
+	}
+	if m.State == Hunting && m.BossPhase(g, ev) {
 		return
 	}
-	if m.State == Hunting && m.RangedAttack(g, ev) {
+	if m.State == Hunting && m.UseItem(g, ev) {
 		return
 	}
-	if m.State == Hunting && m.SmitingAttack(g, ev) {
+	if m.State == Hunting && m.UseAbility(g, ev) {
 		return
 	}
 	switch m.Kind {
@@ -1941,9 +1919,16 @@ func (m *monster) HandleTurn(g *game, ev event) {
 			return
 		}
 	}
+	if m.HasEffect(EffFear) && m.State == Hunting && mpos.Distance(ppos) <= 2 {
+		m.Path = nil
+		safepos := m.SafePlacement(g)
+		if safepos != nil {
+			m.Target = *safepos
+		}
+	}
 	if mpos.Distance(ppos) == 1 {
 		attack := true
-		if m.Status(MonsConfused) {
+		if m.HasEffect(EffConfusion) {
 			switch m.Pos.Dir(g.Player.Pos) {
 			case E, N, W, S:
 			default:
@@ -1954,7 +1939,7 @@ func (m *monster) HandleTurn(g *game, ev event) {
 					m.Target = *safepos
 				}
 			}
-		} else if m.Kind == MonsMindCelmist {
+		} else if m.Kind == MonsMindCelmist || m.HasEffect(EffFear) {
 			// we can avoid melee
 			safepos := m.SafePlacement(g)
 			m.Path = nil
@@ -1968,7 +1953,7 @@ func (m *monster) HandleTurn(g *game, ev event) {
 			return
 		}
 	}
-	if m.Status(MonsLignified) {
+	if m.HasEffect(EffLignification) {
 		ev.Renew(g, 10) // wait
 		return
 	}
@@ -1981,7 +1966,7 @@ func (m *monster) HandleTurn(g *game, ev event) {
 	m.Obstructing = false
 	if !(len(m.Path) > 0 && m.Path[0] == m.Target && m.Path[len(m.Path)-1] == mpos) {
 		m.Path = m.APath(g, mpos, m.Target)
-		if len(m.Path) == 0 && !m.Status(MonsConfused) {
+		if len(m.Path) == 0 && !m.HasEffect(EffConfusion) {
 			// if target is not accessible, try free neighbor cells
 			for _, npos := range g.Dungeon.FreeNeighbors(m.Target) {
 				m.Path = m.APath(g, mpos, npos)
@@ -2037,7 +2022,7 @@ func (m *monster) HandleTurn(g *game, ev event) {
 			}
 			m.MoveTo(g, target)
 			m.Path = m.Path[:len(m.Path)-1]
-		} else if g.Dungeon.Cell(target).T == WallCell {
+		} else if g.Dungeon.Cell(target).T == WallCell || !m.CanPass(g, target) {
 			m.Path = m.APath(g, mpos, m.Target)
 		} else {
 			m.InvertFoliage(g)
@@ -2135,8 +2120,7 @@ func (m *monster) Exhaust(g *game) {
 }
 
 func (m *monster) ExhaustTime(g *game, t int) {
-	m.Statuses[MonsExhausted]++
-	g.PushEvent(&monsterEvent{ERank: g.Ev.Rank() + t, NMons: m.Index, EAction: MonsExhaustionEnd})
+	m.AddEffect(g, EffExhausted, t)
 }
 
 func (m *monster) HitPlayer(g *game, ev event) {
@@ -2154,7 +2138,7 @@ func (m *monster) HitPlayer(g *game, ev event) {
 			g.BlockEffects(m)
 			return
 		}
-		if g.Player.HasStatus(StatusSwap) && !g.Player.HasStatus(StatusLignification) && !m.Status(MonsLignified) {
+		if g.Player.HasEffect(EffSwap) && !g.Player.HasEffect(EffLignification) && !m.HasEffect(EffLignification) {
 			g.SwapWithMonster(m)
 			return
 		}
@@ -2164,9 +2148,10 @@ func (m *monster) HitPlayer(g *game, ev event) {
 		if clang {
 			sclang = g.ArmourClang()
 		}
-		g.PrintfStyled("%s hits you (%d dmg).%s", logMonsterHit, m.Kind.Definite(true), attack, sclang)
+		g.PrintfStyled("%s hits you (%d dmg).%s", logMonsterHit, m.SeenName(g, true), attack, sclang)
+		g.UnseenAttack(m)
 		m.InflictDamage(g, attack, m.Attack)
-		if m.Kind == MonsVampire {
+		if m.Kind == MonsVampire || m.HasMod(ChampVampiric) {
 			healing := attack
 			if healing > 2*m.Attack/3 {
 				healing = 2 * m.Attack / 3
@@ -2183,6 +2168,7 @@ func (m *monster) HitPlayer(g *game, ev event) {
 		const HeavyWoundHP = 18
 		if g.Player.Aptitudes[AptConfusingGas] && g.Player.HP < HeavyWoundHP && RandInt(2) == 0 {
 			m.EnterConfusion(g, ev)
+			g.Gas(m.Pos, CloudConfusion, 3, ev)
 			g.Printf("You release some confusing gas against the %s.", m.Kind)
 		}
 		if g.Player.Aptitudes[AptSmoke] && g.Player.HP < HeavyWoundHP && RandInt(2) == 0 {
@@ -2190,11 +2176,12 @@ func (m *monster) HitPlayer(g *game, ev event) {
 		}
 		if g.Player.Aptitudes[AptObstruction] && g.Player.HP <= HeavyWoundHP && RandInt(2) == 0 {
 			opos := m.Pos
-			m.Blink(g)
-			if opos != m.Pos {
+			if g.KnockbackMonster(m, m.Pos.Dir(g.Player.Pos), 3, ev) {
 				g.TemporalWallAt(opos, ev)
 				g.Print("A temporal wall emerges.")
-				m.Exhaust(g)
+				if m.Exists() {
+					m.Exhaust(g)
+				}
 			}
 		}
 		if g.Player.Aptitudes[AptTeleport] && g.Player.HP < HeavyWoundHP && RandInt(2) == 0 {
@@ -2205,25 +2192,22 @@ func (m *monster) HitPlayer(g *game, ev event) {
 		}
 	} else {
 		g.Stats.Dodges++
-		g.Printf("%s misses you.", m.Kind.Definite(true))
+		g.Printf("%s misses you.", m.SeenName(g, true))
+		g.UnseenAttack(m)
 	}
 }
 
 func (m *monster) EnterConfusion(g *game, ev event) {
-	if !m.Status(MonsConfused) {
-		m.Statuses[MonsConfused] = 1
+	if !m.HasEffect(EffConfusion) {
+		m.AddEffect(g, EffConfusion, 50+RandInt(100))
 		m.Path = m.Path[:0]
-		g.PushEvent(&monsterEvent{
-			ERank: ev.Rank() + 50 + RandInt(100), NMons: m.Index, EAction: MonsConfusionEnd})
 	}
 }
 
 func (m *monster) EnterLignification(g *game, ev event) {
-	if !m.Status(MonsLignified) {
-		m.Statuses[MonsLignified] = 1
+	if !m.HasEffect(EffLignification) {
+		m.AddEffect(g, EffLignification, EffLignification.Duration())
 		m.Path = m.Path[:0]
-		g.PushEvent(&monsterEvent{
-			ERank: ev.Rank() + 150 + RandInt(100), NMons: m.Index, EAction: MonsLignificationEnd})
 		if g.Player.LOS[m.Pos] {
 			g.Printf("%s is rooted to the ground.", m.Kind.Definite(true))
 		}
@@ -2237,12 +2221,9 @@ func (m *monster) HitSideEffects(g *game, ev event) {
 			g.Confusion(ev)
 		}
 	case MonsGiantBee:
-		if RandInt(5) == 0 && !g.Player.HasStatus(StatusBerserk) && !g.Player.HasStatus(StatusExhausted) {
-			g.Player.Statuses[StatusBerserk] = 1
+		if RandInt(5) == 0 && !g.Player.HasEffect(EffBerserk) && !g.Player.HasEffect(EffExhausted) {
+			g.PlayerAddEffect(EffBerserk, 25+RandInt(30))
 			g.Player.HP += 10
-			end := ev.Rank() + 25 + RandInt(30)
-			g.PushEvent(&simpleEvent{ERank: end, EAction: BerserkEnd})
-			g.Player.Expire[StatusBerserk] = end
 			g.Print("You feel a sudden urge to kill things.")
 		}
 	case MonsBlinkingFrog:
@@ -2252,11 +2233,12 @@ func (m *monster) HitSideEffects(g *game, ev event) {
 	case MonsAcidMound:
 		g.Corrosion(ev)
 	case MonsYack:
-		if RandInt(2) == 0 && m.PushPlayer(g) {
+		if RandInt(2) == 0 && !g.Player.HasEffect(EffLignification) {
 			g.Print("The yack pushes you.")
+			m.PushPlayer(g, ev)
 		}
 	case MonsWingedMilfid:
-		if m.Status(MonsExhausted) || g.Player.HasStatus(StatusLignification) {
+		if m.HasEffect(EffExhausted) || g.Player.HasEffect(EffLignification) {
 			break
 		}
 		ompos := m.Pos
@@ -2267,60 +2249,9 @@ func (m *monster) HitSideEffects(g *game, ev event) {
 	}
 }
 
-func (m *monster) PushPlayer(g *game) (pushed bool) {
-	dir := g.Player.Pos.Dir(m.Pos)
-	pos := g.Player.Pos.To(dir)
-	if !g.Player.HasStatus(StatusLignification) &&
-		pos.valid() && g.Dungeon.Cell(pos).T == FreeCell {
-		mons := g.MonsterAt(pos)
-		if !mons.Exists() {
-			g.PlacePlayerAt(pos)
-			pushed = true
-		}
-	}
-	return pushed
-}
-
-func (m *monster) RangedAttack(g *game, ev event) bool {
-	if !m.Kind.Ranged() {
-		return false
-	}
-	if m.Pos.Distance(g.Player.Pos) <= 1 && m.Kind != MonsSatowalgaPlant {
-		return false
-	}
-	if !g.Player.LOS[m.Pos] {
-		m.FireReady = false
-		return false
-	}
-	if !m.FireReady {
-		m.FireReady = true
-		if m.Pos.Distance(g.Player.Pos) <= 3 {
-			ev.Renew(g, m.Kind.AttackDelay())
-			return true
-		} else {
-			return false
-		}
-	}
-	if m.Status(MonsExhausted) {
-		return false
-	}
-	switch m.Kind {
-	case MonsLich:
-		return m.TormentBolt(g, ev)
-	case MonsCyclop:
-		return m.ThrowRock(g, ev)
-	case MonsGoblinWarrior:
-		return m.ThrowJavelin(g, ev)
-	case MonsSatowalgaPlant:
-		return m.ThrowAcid(g, ev)
-	case MonsMadNixe:
-		return m.NixeAttraction(g, ev)
-	case MonsVampire:
-		return m.VampireSpit(g, ev)
-	case MonsTreeMushroom:
-		return m.ThrowSpores(g, ev)
-	}
-	return false
+// PushPlayer knocks the player back one cell away from the monster.
+func (m *monster) PushPlayer(g *game, ev event) bool {
+	return g.KnockbackPlayer(g.Player.Pos.Dir(m.Pos), 1, ev)
 }
 
 func (m *monster) RangeBlocked(g *game) bool {
@@ -2337,29 +2268,6 @@ func (m *monster) RangeBlocked(g *game) bool {
 	return blocked
 }
 
-func (m *monster) TormentBolt(g *game, ev event) bool {
-	blocked := m.RangeBlocked(g)
-	if blocked {
-		return false
-	}
-	hit := !m.Blocked(g)
-	g.MakeNoise(9, m.Pos)
-	if hit {
-		g.MakeNoise(MagicHitNoise, g.Player.Pos)
-		damage := g.Player.HP - g.Player.HP/2
-		g.PrintfStyled("%s throws a bolt of torment at you.", logMonsterHit, m.Kind.Definite(true))
-		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorCyan)
-		m.InflictDamage(g, damage, 15)
-	} else {
-		g.Printf("You block the %s's bolt of torment.", m.Kind)
-		g.BlockEffects(m)
-		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorCyan)
-	}
-	m.Exhaust(g)
-	ev.Renew(g, m.Kind.AttackDelay())
-	return true
-}
-
 func (m *monster) Blocked(g *game) bool {
 	blocked := false
 	if g.Player.Shield != NoShield && !g.Player.Weapon.TwoHanded() && !g.Player.Blocked {
@@ -2372,321 +2280,6 @@ func (m *monster) Blocked(g *game) bool {
 	return blocked
 }
 
-func (m *monster) ThrowRock(g *game, ev event) bool {
-	blocked := m.RangeBlocked(g)
-	if blocked {
-		return false
-	}
-	block := false
-	hit := true
-	evasion := RandInt(g.Player.Evasion())
-	acc := RandInt(m.Accuracy)
-	const rockdmg = 15
-	attack, clang := g.HitDamage(DmgPhysical, rockdmg, g.Player.Armor())
-	attack, evasion, clang = m.DramaticAdjustment(g, rockdmg, attack, evasion, acc, clang)
-	if 4*acc/3 <= evasion {
-		// rocks are big and do not miss so often
-		hit = false
-	} else {
-		block = m.Blocked(g)
-		hit = !block
-	}
-	if hit {
-		noise := g.HitNoise(clang)
-		g.MakeNoise(noise, g.Player.Pos)
-		var sclang string
-		if clang {
-			sclang = g.ArmourClang()
-		}
-		g.PrintfStyled("%s throws a rock at you (%d dmg).%s", logMonsterHit, m.Kind.Definite(true), attack, sclang)
-		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '●', ColorMagenta)
-		oppos := g.Player.Pos
-		if m.PushPlayer(g) {
-			g.TemporalWallAt(oppos, ev)
-		} else {
-			ray := g.Ray(m.Pos)
-			if len(ray) > 0 {
-				g.TemporalWallAt(ray[len(ray)-1], ev)
-			}
-		}
-		m.InflictDamage(g, attack, rockdmg)
-	} else if block {
-		g.Printf("You block %s's rock. Clang!", m.Kind.Indefinite(false))
-		g.MakeNoise(ShieldBlockNoise, g.Player.Pos)
-		g.BlockEffects(m)
-		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '●', ColorMagenta)
-		ray := g.Ray(m.Pos)
-		if len(ray) > 0 {
-			g.TemporalWallAt(ray[len(ray)-1], ev)
-		}
-	} else {
-		g.Stats.Dodges++
-		g.Printf("You dodge %s's rock.", m.Kind.Indefinite(false))
-		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '●', ColorMagenta)
-		dir := g.Player.Pos.Dir(m.Pos)
-		pos := g.Player.Pos.To(dir)
-		if pos.valid() {
-			mons := g.MonsterAt(pos)
-			if mons.Exists() {
-				mons.HP -= RandInt(15)
-				if mons.HP <= 0 {
-					g.HandleKill(mons, ev)
-				} else {
-					mons.Blink(g)
-					if mons.Pos != pos {
-						g.TemporalWallAt(pos, ev)
-					}
-				}
-			} else {
-				g.TemporalWallAt(pos, ev)
-			}
-		}
-	}
-	ev.Renew(g, 2*m.Kind.AttackDelay())
-	return true
-}
-
-func (m *monster) VampireSpit(g *game, ev event) bool {
-	blocked := m.RangeBlocked(g)
-	if blocked || g.Player.HasStatus(StatusNausea) {
-		return false
-	}
-	g.Player.Statuses[StatusNausea]++
-	g.PushEvent(&simpleEvent{ERank: ev.Rank() + 30 + RandInt(20), EAction: NauseaEnd})
-	g.Print("The vampire spits at you. You feel sick.")
-	m.Exhaust(g)
-	ev.Renew(g, m.Kind.AttackDelay())
-	return true
-}
-
-func (m *monster) ThrowSpores(g *game, ev event) bool {
-	blocked := m.RangeBlocked(g)
-	if blocked || g.Player.HasStatus(StatusLignification) {
-		return false
-	}
-	g.EnterLignification(ev)
-	g.Print("The tree mushroom releases spores. You feel rooted to the ground.")
-	m.Exhaust(g)
-	ev.Renew(g, m.Kind.AttackDelay())
-	return true
-}
-
-func (m *monster) ThrowJavelin(g *game, ev event) bool {
-	blocked := m.RangeBlocked(g)
-	if blocked {
-		return false
-	}
-	block := false
-	hit := true
-	evasion := RandInt(g.Player.Evasion())
-	acc := RandInt(m.Accuracy)
-	const jdmg = 11
-	attack, clang := g.HitDamage(DmgPhysical, jdmg, g.Player.Armor())
-	attack, evasion, clang = m.DramaticAdjustment(g, jdmg, attack, evasion, acc, clang)
-	if acc <= evasion {
-		hit = false
-	} else {
-		block = m.Blocked(g)
-		hit = !block
-	}
-	if hit {
-		noise := g.HitNoise(clang)
-		g.MakeNoise(noise, g.Player.Pos)
-		var sclang string
-		if clang {
-			sclang = g.ArmourClang()
-		}
-		g.Printf("%s throws %s at you (%d dmg).%s", m.Kind.Definite(true), Indefinite("javelin", false), attack, sclang)
-		g.ui.MonsterJavelinAnimation(g.Ray(m.Pos), true)
-		m.InflictDamage(g, attack, jdmg)
-	} else if block {
-		if RandInt(3) == 0 {
-			g.Printf("You block %s's %s. Clang!", m.Kind.Indefinite(false), "javelin")
-			g.MakeNoise(ShieldBlockNoise, g.Player.Pos)
-			g.BlockEffects(m)
-			g.ui.MonsterJavelinAnimation(g.Ray(m.Pos), false)
-		} else if !g.Player.HasStatus(StatusDisabledShield) {
-			g.Player.Statuses[StatusDisabledShield] = 1
-			g.PushEvent(&simpleEvent{ERank: ev.Rank() + 100 + RandInt(100), EAction: DisabledShieldEnd})
-			g.Printf("%s's %s gets embedded in your shield.", m.Kind.Indefinite(true), "javelin")
-			g.MakeNoise(ShieldBlockNoise, g.Player.Pos)
-			g.ui.MonsterJavelinAnimation(g.Ray(m.Pos), false)
-		}
-	} else {
-		g.Stats.Dodges++
-		g.Printf("You dodge %s's %s.", m.Kind.Indefinite(false), "javelin")
-		g.ui.MonsterJavelinAnimation(g.Ray(m.Pos), false)
-	}
-	m.ExhaustTime(g, 50+RandInt(50))
-	ev.Renew(g, m.Kind.AttackDelay())
-	return true
-}
-
-func (m *monster) ThrowAcid(g *game, ev event) bool {
-	blocked := m.RangeBlocked(g)
-	if blocked {
-		return false
-	}
-	block := false
-	hit := true
-	evasion := RandInt(g.Player.Evasion())
-	acc := RandInt(m.Accuracy)
-	acdmg := 12
-	attack, clang := g.HitDamage(DmgPhysical, acdmg, g.Player.Armor())
-	attack, evasion, clang = m.DramaticAdjustment(g, acdmg, attack, evasion, acc, clang)
-	if acc <= evasion {
-		hit = false
-	} else {
-		block = m.Blocked(g)
-		hit = !block
-	}
-	if hit {
-		noise := g.HitNoise(false) // no clang with acid projectiles
-		g.MakeNoise(noise, g.Player.Pos)
-		g.Printf("%s throws acid at you (%d dmg).", m.Kind.Definite(true), attack)
-		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorGreen)
-		m.InflictDamage(g, attack, acdmg)
-		if RandInt(2) == 0 {
-			g.Corrosion(ev)
-			if RandInt(2) == 0 {
-				g.Confusion(ev)
-			}
-		}
-	} else if block {
-		g.Printf("You block %s's acid projectile.", m.Kind.Indefinite(false))
-		g.MakeNoise(BaseHitNoise, g.Player.Pos) // no real clang
-		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorGreen)
-		if RandInt(2) == 0 {
-			g.Corrosion(ev)
-		}
-	} else {
-		g.Stats.Dodges++
-		g.Printf("You dodge %s's acid projectile.", m.Kind.Indefinite(false))
-		g.ui.MonsterProjectileAnimation(g.Ray(m.Pos), '*', ColorGreen)
-	}
-	ev.Renew(g, m.Kind.AttackDelay())
-	return true
-}
-
-func (m *monster) NixeAttraction(g *game, ev event) bool {
-	blocked := m.RangeBlocked(g)
-	if blocked {
-		return false
-	}
-	g.MakeNoise(9, m.Pos)
-	g.PrintfStyled("%s lures you to her.", logMonsterHit, m.Kind.Definite(true))
-	ray := g.Ray(m.Pos)
-	g.ui.MonsterProjectileAnimation(ray, 'θ', ColorCyan) // TODO: improve
-	if len(ray) > 1 {
-		// should always be the case
-		g.ui.TeleportAnimation(g.Player.Pos, ray[1], true)
-		g.PlacePlayerAt(ray[1])
-	}
-	m.Exhaust(g)
-	ev.Renew(g, m.Kind.AttackDelay())
-	return true
-}
-
-func (m *monster) SmitingAttack(g *game, ev event) bool {
-	if !m.Kind.Smiting() {
-		return false
-	}
-	if !g.Player.LOS[m.Pos] {
-		m.FireReady = false
-		return false
-	}
-	if !m.FireReady {
-		m.FireReady = true
-		if m.Pos.Distance(g.Player.Pos) <= 3 {
-			ev.Renew(g, m.Kind.AttackDelay())
-			return true
-		} else {
-			return false
-		}
-	}
-	if m.Status(MonsExhausted) {
-		return false
-	}
-	switch m.Kind {
-	case MonsMirrorSpecter:
-		return m.AbsorbMana(g, ev)
-	case MonsMindCelmist:
-		return m.MindAttack(g, ev)
-	}
-	return false
-}
-
-func (m *monster) AbsorbMana(g *game, ev event) bool {
-	if g.Player.MP == 0 {
-		return false
-	}
-	g.Player.MP -= 1
-	g.Printf("%s absorbs your mana.", m.Kind.Definite(true))
-	m.ExhaustTime(g, 10+RandInt(10))
-	ev.Renew(g, m.Kind.AttackDelay())
-	return true
-}
-
-func (m *monster) MindAttack(g *game, ev event) bool {
-	if g.Player.Pos.Distance(m.Pos) == 1 && (m.HP < m.HPmax || RandInt(2) == 0) {
-		// try to avoid melee
-		safepos := m.SafePlacement(g)
-		if safepos != nil {
-			return false
-		}
-	}
-	dmg := 3 + RandInt(m.Attack) + RandInt(m.Attack) + RandInt(m.Attack)
-	dmg /= 3
-	m.InflictDamage(g, dmg, m.Attack)
-	g.Printf("The celmist mage hurts your mind (%d dmg).", dmg)
-	if RandInt(2) == 0 {
-		if RandInt(2) == 0 {
-			g.Player.Statuses[StatusSlow]++
-			g.PushEvent(&simpleEvent{ERank: ev.Rank() + 30 + RandInt(10), EAction: SlowEnd})
-		} else {
-			g.Confusion(ev)
-		}
-	}
-	ev.Renew(g, m.Kind.AttackDelay())
-	return true
-}
-
-func (m *monster) Explode(g *game, ev event) {
-	neighbors := m.Pos.ValidNeighbors()
-	g.MakeNoise(WallNoise, m.Pos)
-	g.Printf("%s %s explodes with a loud boom.", g.ExplosionSound(), m.Kind.Definite(true))
-	g.ui.ExplosionAnimation(FireExplosion, m.Pos)
-	for _, pos := range append(neighbors, m.Pos) {
-		c := g.Dungeon.Cell(pos)
-		if c.T == FreeCell {
-			g.Burn(pos, ev)
-		}
-		mons := g.MonsterAt(pos)
-		if mons.Exists() {
-			mons.HP /= 2
-			if mons.HP == 0 {
-				mons.HP = 1
-			}
-			g.MakeNoise(ExplosionHitNoise, mons.Pos)
-			g.HandleStone(mons)
-			mons.MakeHuntIfHurt(g)
-		} else if g.Player.Pos == pos {
-			dmg := g.Player.HP / 2
-			m.InflictDamage(g, dmg, 15)
-		} else if c.T == WallCell && RandInt(2) == 0 {
-			g.Dungeon.SetCell(pos, FreeCell)
-			g.Stats.Digs++
-			if !g.Player.LOS[pos] {
-				g.WrongWall[pos] = true
-			} else {
-				g.ui.WallExplosionAnimation(pos)
-			}
-			g.MakeNoise(WallNoise, pos)
-			g.Fog(pos, 1, ev)
-		}
-	}
-}
-
 func (m *monster) Blink(g *game) {
 	npos := g.BlinkPos()
 	if !npos.valid() || npos == g.Player.Pos || npos == m.Pos {
@@ -2733,7 +2326,7 @@ func (m *monster) MakeAware(g *game) {
 		return
 	}
 	if m.State == Resting {
-		if m.Status(MonsExhausted) && (m.Pos.Distance(g.Player.Pos) > 1 || RandInt(3) > 0) {
+		if m.HasEffect(EffExhausted) && (m.Pos.Distance(g.Player.Pos) > 1 || RandInt(3) > 0) {
 			return
 		}
 		adjust := g.LosRange() - m.Pos.Distance(g.Player.Pos)
@@ -2816,7 +2409,7 @@ func (m *monster) GatherBand(g *game) {
 				continue
 			}
 			n, ok := nm[mons.Pos]
-			if !ok || n.Cost > 4 || mons.State == Resting && mons.Status(MonsExhausted) && RandInt(2) == 0 {
+			if !ok || n.Cost > 4 || mons.State == Resting && mons.HasEffect(EffExhausted) && RandInt(2) == 0 {
 				continue
 			}
 			r := RandInt(100)
@@ -2844,7 +2437,7 @@ func (g *game) MonsterAt(pos position) *monster {
 func (g *game) Danger() int {
 	danger := 0
 	for _, mons := range g.Monsters {
-		danger += mons.Kind.Dangerousness()
+		danger += mons.Danger()
 	}
 	return danger
 }
@@ -2914,6 +2507,7 @@ func (g *game) MaxDanger() int {
 	case GenBSPMap:
 		max = max * 115 / 100
 	}
+	max = max * g.Opts.Difficulty.DangerPercent() / 100
 	return max
 }
 
@@ -2938,6 +2532,7 @@ func (g *game) MaxMonsters() int {
 	case GenBSPMap:
 		max = max * 110 / 100
 	}
+	max = max * g.Opts.Difficulty.MonstersPercent() / 100
 	return max
 }
 
@@ -2982,6 +2577,7 @@ loop:
 				nmons--
 				mons := &monster{Kind: mk}
 				mons.Init()
+				danger -= g.MakeChampion(mons, danger)
 				mons.Index = i
 				mons.Band = nband
 				mons.PlaceAt(g, pos)
@@ -2996,7 +2592,7 @@ loop:
 
 func (g *game) MonsterInLOS() *monster {
 	for _, mons := range g.Monsters {
-		if mons.Exists() && g.Player.LOS[mons.Pos] {
+		if mons.Visible(g) {
 			return mons
 		}
 	}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (mk monsterKind) UsesItems() bool {
+	switch mk {
+	case MonsGoblinWarrior, MonsLich, MonsMindCelmist, MonsVampire:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *monster) PickUp(g *game) {
+	if !m.Kind.UsesItems() {
+		return
+	}
+	pos := m.Pos
+	if c, ok := g.Collectables[pos]; ok {
+		m.AddItem(c)
+		delete(g.Collectables, pos)
+		g.DijkstraMapRebuild = true
+		if m.Visible(g) {
+			g.Printf("%s picks up %s.", m.Kind.Definite(true), c.Text())
+		}
+	}
+	if eq, ok := g.Equipables[pos]; ok {
+		m.Gear = append(m.Gear, eq)
+		delete(g.Equipables, pos)
+		g.DijkstraMapRebuild = true
+		if m.Visible(g) {
+			g.Printf("%s picks up %s.", m.Kind.Definite(true), Indefinite(eq.String(), false))
+		}
+	}
+}
+
+func (c collectable) Text() string {
+	if c.Quantity > 1 {
+		return fmt.Sprintf("%d %s", c.Quantity, c.Consumable.Plural())
+	}
+	return Indefinite(c.Consumable.String(), false)
+}
+
+func (m *monster) AddItem(c collectable) {
+	for i, it := range m.Items {
+		if it.Consumable == c.Consumable {
+			m.Items[i].Quantity += c.Quantity
+			return
+		}
+	}
+	m.Items = append(m.Items, c)
+}
+
+func (m *monster) HasItem(c consumable) bool {
+	for _, it := range m.Items {
+		if it.Consumable == c && it.Quantity > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *monster) ConsumeItem(c consumable) {
+	for i, it := range m.Items {
+		if it.Consumable != c {
+			continue
+		}
+		m.Items[i].Quantity--
+		if m.Items[i].Quantity <= 0 {
+			m.Items = append(m.Items[:i], m.Items[i+1:]...)
+		}
+		return
+	}
+}
+
+func (m *monster) ItemsText() string {
+	items := []string{}
+	for _, it := range m.Items {
+		items = append(items, it.Text())
+	}
+	for _, eq := range m.Gear {
+		items = append(items, Indefinite(eq.String(), false))
+	}
+	for _, r := range m.Rods {
+		items = append(items, Indefinite(r.String(), false))
+	}
+	return strings.Join(items, ", ")
+}
+
+// UseItem makes a hunting monster drink a potion or throw a magara it
+// carries, when useful. It returns true if the monster used its turn.
+func (m *monster) UseItem(g *game, ev event) bool {
+	if len(m.Items) == 0 || m.HasEffect(EffConfusion) {
+		return false
+	}
+	switch {
+	case m.HP < m.HPmax/2 && m.HasItem(HealWoundsPotion):
+		m.ConsumeItem(HealWoundsPotion)
+		m.HP += 2 * m.HPmax / 3
+		if m.HP > m.HPmax {
+			m.HP = m.HPmax
+		}
+		if m.Visible(g) {
+			g.Printf("%s quaffs %s.", m.Kind.Definite(true), Indefinite(HealWoundsPotion.String(), false))
+		}
+	case m.HP < 2*m.HPmax/3 && m.HasItem(SwiftnessPotion) && !m.HasEffect(EffSwift):
+		m.ConsumeItem(SwiftnessPotion)
+		if m.Visible(g) {
+			g.Printf("%s quaffs %s.", m.Kind.Definite(true), Indefinite(SwiftnessPotion.String(), false))
+		}
+		m.AddEffect(g, EffSwift, EffSwift.Duration())
+	default:
+		return m.ThrowMagara(g, ev)
+	}
+	ev.Renew(g, m.Kind.AttackDelay())
+	return true
+}
+
+func (m *monster) ThrowMagara(g *game, ev event) bool {
+	dist := m.Pos.Distance(g.Player.Pos)
+	if !g.Player.LOS[m.Pos] || dist < 2 || dist > 6 || m.RangeBlocked(g) || RandInt(2) == 0 {
+		return false
+	}
+	var mag projectile
+	found := false
+	for _, c := range []projectile{ExplosiveMagara, NightMagara, SlowingMagara, ConfuseMagara} {
+		if m.HasItem(c) {
+			mag = c
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	m.ConsumeItem(mag)
+	name := m.SeenName(g, true)
+	switch mag {
+	case ExplosiveMagara:
+		g.Printf("%s throws %s at you... %s", name, Indefinite(mag.String(), false), g.ExplosionSound())
+		g.MakeNoise(ExplosionNoise, g.Player.Pos)
+		g.ui.ExplosionAnimation(FireExplosion, g.Player.Pos)
+		for _, pos := range g.Player.Pos.ValidNeighbors() {
+			if pos != m.Pos {
+				g.ExplosionAt(ev, pos)
+			}
+		}
+		g.Burn(g.Player.Pos, ev)
+		m.InflictDamage(g, Max(1, g.Player.HP/2), 15)
+		g.ExplosionKnockback(g.Player.Pos, ev)
+	case NightMagara:
+		g.Printf("%s throws %s at you.", name, Indefinite(mag.String(), false))
+		g.NightFog(g.Player.Pos, 1, ev)
+	case SlowingMagara:
+		g.Printf("%s throws %s at you. You feel slow.", name, Indefinite(mag.String(), false))
+		g.PlayerAddEffect(EffSlow, 60+RandInt(20))
+	case ConfuseMagara:
+		g.Printf("%s activates %s.", name, Indefinite(mag.String(), false))
+		g.Confusion(ev)
+	}
+	g.UnseenAttack(m)
+	g.StopAuto()
+	ev.Renew(g, m.Kind.AttackDelay())
+	return true
+}
+
+// DropItems drops the items carried by a dead monster around its position,
+// spilling further out when nearby cells are already occupied.
+func (m *monster) DropItems(g *game) {
+	if len(m.Items) == 0 && len(m.Gear) == 0 && len(m.Rods) == 0 {
+		return
+	}
+	free := []position{m.Pos}
+	visited := map[position]bool{m.Pos: true}
+	i := 0
+	next := func() position {
+		for i < len(free) {
+			pos := free[i]
+			i++
+			for _, npos := range g.Dungeon.FreeNeighbors(pos) {
+				if !visited[npos] {
+					visited[npos] = true
+					free = append(free, npos)
+				}
+			}
+			if g.ObjectAt(pos) || g.IsDoor(pos) {
+				continue
+			}
+			if _, ok := g.Stairs[pos]; ok {
+				continue
+			}
+			if _, ok := g.MagicalStones[pos]; ok {
+				continue
+			}
+			return pos
+		}
+		return g.FreeCellForStatic()
+	}
+	for _, it := range m.Items {
+		g.Collectables[next()] = it
+	}
+	for _, eq := range m.Gear {
+		g.Equipables[next()] = eq
+	}
+	for _, r := range m.Rods {
+		g.Rods[next()] = r
+	}
+	if g.Player.LOS[m.Pos] {
+		// the monster is dead: Visible does not apply
+		name := "Something"
+		if !m.Invisible() {
+			name = m.Kind.Definite(true)
+		}
+		g.Printf("%s drops %s.", name, m.ItemsText())
+	}
+	m.Items = nil
+	m.Gear = nil
+	m.Rods = nil
+	g.DijkstraMapRebuild = true
+}
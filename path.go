@@ -39,10 +39,13 @@ func (pp *playerPath) Neighbors(pos position) []position {
 		if cld, ok := pp.game.Clouds[npos]; ok && cld == CloudFire && !(pp.game.WrongDoor[npos] || pp.game.WrongFoliage[npos]) {
 			return false
 		}
-		return npos.valid() && ((d.Cell(npos).T == FreeCell && !pp.game.WrongWall[npos] || d.Cell(npos).T == WallCell && pp.game.WrongWall[npos]) || pp.game.Player.HasStatus(StatusDig)) &&
+		if pp.game.LockedDoorBlocks(npos) {
+			return false
+		}
+		return npos.valid() && ((d.Cell(npos).T == FreeCell && !pp.game.WrongWall[npos] || d.Cell(npos).T == WallCell && pp.game.WrongWall[npos]) || pp.game.Player.HasEffect(EffDig)) &&
 			d.Cell(npos).Explored
 	}
-	if pp.game.Player.HasStatus(StatusConfusion) {
+	if pp.game.Player.HasEffect(EffConfusion) {
 		nb = pos.CardinalNeighbors(nb, keep)
 	} else {
 		nb = pos.Neighbors(nb, keep)
@@ -90,7 +93,7 @@ func (np *normalPath) Neighbors(pos position) []position {
 	keep := func(npos position) bool {
 		return npos.valid() && d.Cell(npos).T != WallCell
 	}
-	if np.game.Player.HasStatus(StatusConfusion) {
+	if np.game.Player.HasEffect(EffConfusion) {
 		return pos.CardinalNeighbors(nb, keep)
 	}
 	return pos.Neighbors(nb, keep)
@@ -116,10 +119,16 @@ func (ap *autoexplorePath) Neighbors(pos position) []position {
 			// XXX little info leak
 			return false
 		}
+		if ap.game.LockedDoorBlocks(npos) {
+			return false
+		}
+		if ap.game.ExploreHazard(npos) {
+			return false
+		}
 		return npos.valid() && (d.Cell(npos).T == FreeCell && !ap.game.WrongWall[npos] || d.Cell(npos).T == WallCell && ap.game.WrongWall[npos]) &&
 			!ap.game.ExclusionsMap[npos]
 	}
-	if ap.game.Player.HasStatus(StatusConfusion) {
+	if ap.game.Player.HasEffect(EffConfusion) {
 		nb = pos.CardinalNeighbors(nb, keep)
 	} else {
 		nb = pos.Neighbors(nb, keep)
@@ -128,6 +137,9 @@ func (ap *autoexplorePath) Neighbors(pos position) []position {
 }
 
 func (ap *autoexplorePath) Cost(from, to position) int {
+	if ap.game.ClosedDoor(to) {
+		return 2
+	}
 	return 1
 }
 
@@ -142,9 +154,9 @@ func (mp *monPath) Neighbors(pos position) []position {
 	nb := mp.neighbors[:0]
 	d := mp.game.Dungeon
 	keep := func(npos position) bool {
-		return npos.valid() && (d.Cell(npos).T != WallCell || mp.wall)
+		return npos.valid() && (d.Cell(npos).T != WallCell || mp.wall) && mp.monster.CanPass(mp.game, npos)
 	}
-	if mp.monster.Status(MonsConfused) {
+	if mp.monster.HasEffect(EffConfusion) {
 		return pos.CardinalNeighbors(nb, keep)
 	}
 	return pos.Neighbors(nb, keep)
@@ -157,9 +169,12 @@ func (mp *monPath) Cost(from, to position) int {
 		if mp.wall && g.Dungeon.Cell(to).T == WallCell && mp.monster.State != Hunting {
 			return 6
 		}
+		if g.ClosedDoor(to) {
+			return 2
+		}
 		return 1
 	}
-	if mons.Status(MonsLignified) {
+	if mons.HasEffect(EffLignification) {
 		return 8
 	}
 	return 4
@@ -9,14 +9,13 @@ type player struct {
 	HP          int
 	MP          int
 	Simellas    int
+	Keys        int
 	Armour      armour
 	Weapon      weapon
 	Shield      shield
 	Consumables map[consumable]int
 	Rods        map[rod]rodProps
 	Aptitudes   map[aptitude]bool
-	Statuses    map[status]int
-	Expire      map[status]int
 	Pos         position
 	Target      position
 	LOS         map[position]bool
@@ -24,12 +23,14 @@ type player struct {
 	Bored       int
 	AccScore    int
 	Blocked     bool
+	Background  background
+	Effects     effects
 }
 
 const DefaultHealth = 42
 
 func (p *player) HPMax() int {
-	hpmax := DefaultHealth
+	hpmax := DefaultHealth + p.Background.HPBonus()
 	if p.Aptitudes[AptHealthy] {
 		hpmax += 10
 	}
@@ -44,7 +45,7 @@ func (p *player) HPMax() int {
 }
 
 func (p *player) MPMax() int {
-	mpmax := 3
+	mpmax := 3 + p.Background.MPBonus()
 	if p.Aptitudes[AptMagic] {
 		mpmax += 2
 	}
@@ -54,6 +55,11 @@ func (p *player) MPMax() int {
 	return mpmax
 }
 
+func (p *player) HasRod(r rod) bool {
+	_, ok := p.Rods[r]
+	return ok
+}
+
 func (p *player) Accuracy() int {
 	acc := 15
 	return acc
@@ -77,11 +83,11 @@ func (p *player) Armor() int {
 	if p.Aptitudes[AptScales] {
 		ar += 2
 	}
-	if p.HasStatus(StatusLignification) {
+	if p.HasEffect(EffLignification) {
 		ar = 9 + ar/2
 	}
-	if p.HasStatus(StatusCorrosion) {
-		ar -= 2 * p.Statuses[StatusCorrosion]
+	if p.HasEffect(EffCorrosion) {
+		ar -= 2 * p.Effects.Intensity(EffCorrosion)
 		if ar < 0 {
 			ar = 0
 		}
@@ -94,8 +100,8 @@ func (p *player) Attack() int {
 	if p.Aptitudes[AptStrong] {
 		attack += attack / 5
 	}
-	if p.HasStatus(StatusCorrosion) {
-		penalty := p.Statuses[StatusCorrosion]
+	if p.HasEffect(EffCorrosion) {
+		penalty := p.Effects.Intensity(EffCorrosion)
 		if penalty > 5 {
 			penalty = 5
 		}
@@ -106,7 +112,7 @@ func (p *player) Attack() int {
 
 func (p *player) Block() int {
 	block := p.Shield.Block()
-	if p.HasStatus(StatusDisabledShield) {
+	if p.HasEffect(EffDisabledShield) {
 		block /= 3
 	}
 	return block
@@ -125,16 +131,12 @@ func (p *player) Evasion() int {
 	case SpeedRobe:
 		ev += 3
 	}
-	if p.HasStatus(StatusAgile) {
+	if p.HasEffect(EffAgile) {
 		ev += 7
 	}
 	return ev
 }
 
-func (p *player) HasStatus(st status) bool {
-	return p.Statuses[st] > 0
-}
-
 func (p *player) AptitudeCount() int {
 	count := 0
 	for _, b := range p.Aptitudes {
@@ -172,6 +174,27 @@ func (g *game) GoToDir(dir direction, ev event) error {
 	return nil
 }
 
+func (g *game) GoToStairs(ev event) error {
+	stairs := g.StairsSlice()
+	sortedStairs := g.SortedNearestTo(stairs, g.Player.Pos)
+	if len(sortedStairs) == 0 {
+		return errors.New("You cannot go to any stairs.")
+	}
+	stair := sortedStairs[0]
+	if g.Player.Pos == stair {
+		return errors.New("You are already on the stairs.")
+	}
+	ex := &examiner{stairs: true}
+	err := ex.Action(g, stair)
+	if err == nil && !g.MoveToTarget(ev) {
+		err = errors.New("You could not move toward stairs.")
+	}
+	if ex.Done() {
+		g.Targeting = InvalidPos
+	}
+	return err
+}
+
 func (g *game) MoveToTarget(ev event) bool {
 	if !g.AutoTarget.valid() {
 		return false
@@ -281,12 +304,7 @@ func (g *game) Rest(ev event) error {
 }
 
 func (g *game) StatusRest() bool {
-	for _, q := range g.Player.Statuses {
-		if q > 0 {
-			return true
-		}
-	}
-	return false
+	return len(g.Player.Effects.Sorted()) > 0
 }
 
 func (g *game) NeedsRegenRest() bool {
@@ -300,6 +318,12 @@ func (g *game) Equip(ev event) error {
 		g.BoredomAction(ev, 1)
 		return nil
 	}
+	if _, ok := g.Rods[g.Player.Pos]; ok {
+		return g.EnhanceRod(ev)
+	}
+	if stn, ok := g.MagicalStones[g.Player.Pos]; ok && stn != InertStone {
+		return g.EnhanceRod(ev)
+	}
 	return errors.New("Found nothing to equip here.")
 }
 
@@ -344,6 +368,12 @@ func (g *game) CollectGround() {
 		g.DijkstraMapRebuild = true
 		delete(g.Simellas, pos)
 	}
+	if g.Keys[pos] {
+		g.Player.Keys++
+		g.DijkstraMapRebuild = true
+		delete(g.Keys, pos)
+		g.Print("You pick up a key. It should open a locked door on this level.")
+	}
 	if c, ok := g.Collectables[pos]; ok {
 		g.Player.Consumables[c.Consumable] += c.Quantity
 		g.DijkstraMapRebuild = true
@@ -357,19 +387,27 @@ func (g *game) CollectGround() {
 		}
 	}
 	if r, ok := g.Rods[pos]; ok {
-		g.Player.Rods[r] = rodProps{Charge: r.MaxCharge() - 1}
-		g.DijkstraMapRebuild = true
-		delete(g.Rods, pos)
-		g.Printf("You take a %s.", r)
-		g.StoryPrintf("Found and took a %s.", r)
+		if _, ok := g.Player.Rods[r]; ok {
+			g.Printf("You are standing over a duplicate %s. You may sacrifice it to enhance your own.", r)
+		} else {
+			g.Player.Rods[r] = rodProps{Charge: r.MaxCharge() - 1}
+			g.DijkstraMapRebuild = true
+			delete(g.Rods, pos)
+			g.Printf("You take a %s.", r)
+			g.StoryPrintf("Found and took a %s.", r)
+		}
 	}
 	if eq, ok := g.Equipables[pos]; ok {
+		if !g.VisitedItems[pos] {
+			g.VisitedItems[pos] = true
+			g.DijkstraMapRebuild = true
+		}
 		g.Printf("You are standing over %s.", Indefinite(eq.String(), false))
 	} else if _, ok := g.Stairs[pos]; ok {
 		g.Print("You are standing on a staircase.")
 	} else if stn, ok := g.MagicalStones[pos]; ok {
 		g.Printf("You are standing on %s.", Indefinite(stn.String(), false))
-	} else if g.Doors[pos] {
+	} else if g.IsDoor(pos) {
 		g.Print("You stand at the door.")
 	}
 }
@@ -379,10 +417,10 @@ func (g *game) MovePlayer(pos position, ev event) error {
 		return errors.New("You cannot move there.")
 	}
 	c := g.Dungeon.Cell(pos)
-	if c.T == WallCell && !g.Player.HasStatus(StatusDig) {
+	if c.T == WallCell && !g.Player.HasEffect(EffDig) {
 		return errors.New("You cannot move into a wall.")
 	}
-	if g.Player.HasStatus(StatusConfusion) {
+	if g.Player.HasEffect(EffConfusion) {
 		switch pos.Dir(g.Player.Pos) {
 		case E, N, W, S:
 		default:
@@ -395,9 +433,12 @@ func (g *game) MovePlayer(pos position, ev event) error {
 		mons = g.AttractMonster(pos)
 	}
 	if !mons.Exists() {
-		if g.Player.HasStatus(StatusLignification) {
+		if g.Player.HasEffect(EffLignification) {
 			return errors.New("You cannot move while lignified")
 		}
+		if d, ok := g.Doors[pos]; ok && d == DoorLocked {
+			return g.UnlockDoor(pos, ev)
+		}
 		if c.T == WallCell {
 			g.Dungeon.SetCell(pos, FreeCell)
 			g.MakeNoise(WallNoise, pos)
@@ -421,7 +462,7 @@ func (g *game) MovePlayer(pos position, ev event) error {
 				g.PushEvent(&cloudEvent{ERank: ev.Rank() + 15 + RandInt(10), EAction: CloudEnd, Pos: g.Player.Pos})
 			}
 		}
-		if g.Player.HasStatus(StatusSwift) {
+		if g.Player.HasEffect(EffSwift) {
 			// only fast for movement
 			delay -= 3
 		}
@@ -430,18 +471,22 @@ func (g *game) MovePlayer(pos position, ev event) error {
 		if !g.Autoexploring {
 			g.BoredomAction(ev, 1)
 		}
-		if g.Player.Statuses[StatusSlay] > 0 {
-			g.Player.Statuses[StatusSlay] /= 2
+		if st := g.Player.Effects[EffSlay]; st.Intensity > 0 {
+			st.Intensity /= 2
+			g.Player.Effects[EffSlay] = st
 		}
 	} else {
+		if g.Player.HasEffect(EffFear) {
+			return errors.New("You are too afraid to attack.")
+		}
 		g.FunAction()
 		g.AttackMonster(mons, ev)
 	}
-	if g.Player.HasStatus(StatusBerserk) {
+	if g.Player.HasEffect(EffBerserk) {
 		delay -= 3
 	}
-	if g.Player.HasStatus(StatusSlow) {
-		delay += 3 * g.Player.Statuses[StatusSlow]
+	if g.Player.HasEffect(EffSlow) {
+		delay += 3 * g.Player.Effects.Intensity(EffSlow)
 	}
 	if delay < 3 {
 		delay = 3
@@ -454,7 +499,7 @@ func (g *game) HealPlayer(ev event) {
 	if g.Player.HP < g.Player.HPMax() {
 		g.Player.HP++
 	}
-	delay := 50
+	delay := g.Opts.Difficulty.HealDelay()
 	ev.Renew(g, delay)
 }
 
@@ -462,7 +507,7 @@ func (g *game) MPRegen(ev event) {
 	if g.Player.MP < g.Player.MPMax() {
 		g.Player.MP++
 	}
-	delay := 100
+	delay := g.Opts.Difficulty.MPRegenDelay()
 	ev.Renew(g, delay)
 }
 
@@ -476,37 +521,32 @@ func (g *game) Smoke(ev event) {
 			g.PushEvent(&cloudEvent{ERank: ev.Rank() + 100 + RandInt(100), EAction: CloudEnd, Pos: pos})
 		}
 	}
-	g.Player.Statuses[StatusSwift]++
-	end := ev.Rank() + 20 + RandInt(10)
-	g.PushEvent(&simpleEvent{ERank: end, EAction: HasteEnd})
-	g.Player.Expire[StatusSwift] = end
+	g.PlayerAddEffect(EffSwift, 20+RandInt(10))
 	g.ComputeLOS()
 	g.Print("You feel an energy burst and smoke comes out from you.")
 }
 
 func (g *game) Corrosion(ev event) {
-	g.Player.Statuses[StatusCorrosion]++
-	g.PushEvent(&simpleEvent{ERank: ev.Rank() + 80 + RandInt(40), EAction: CorrosionEnd})
+	g.PlayerAddEffect(EffCorrosion, EffCorrosion.Duration())
 	g.Print("Your equipment gets corroded.")
 }
 
 func (g *game) Confusion(ev event) {
-	if !g.Player.HasStatus(StatusConfusion) {
-		g.Player.Statuses[StatusConfusion]++
-		g.PushEvent(&simpleEvent{ERank: ev.Rank() + 100 + RandInt(100), EAction: ConfusionEnd})
+	if !g.Player.HasEffect(EffConfusion) {
+		g.PlayerAddEffect(EffConfusion, EffConfusion.Duration())
 		g.Print("You feel confused.")
 	}
 }
 
 func (g *game) PlacePlayerAt(pos position) {
 	g.Player.Pos = pos
+	g.OpenDoor(pos)
 	g.CollectGround()
 	g.ComputeLOS()
 	g.MakeMonstersAware()
 }
 
 func (g *game) EnterLignification(ev event) {
-	g.Player.Statuses[StatusLignification]++
-	g.PushEvent(&simpleEvent{ERank: ev.Rank() + 150 + RandInt(100), EAction: LignificationEnd})
+	g.PlayerAddEffect(EffLignification, EffLignification.Duration())
 	g.Player.HP += 10
 }
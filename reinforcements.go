@@ -0,0 +1,120 @@
+package main
+
+// ReinforcementWarning is the number of turns between the warning and the
+// arrival of reinforcements.
+const ReinforcementWarning = 50
+
+func (g *game) InitReinforcements() {
+	g.LevelNoise = 0
+	g.ReinforcementWarned = false
+	g.ReinforcementTurn = g.Opts.Difficulty.ReinforcementDelay()
+}
+
+// ReinforcementTime is the time spent on the level as seen by the
+// monsters arriving from elsewhere: noisy fights make it go faster.
+func (g *game) ReinforcementTime() int {
+	return g.DepthPlayerTurn + g.LevelNoise/10
+}
+
+func (g *game) LivingDanger() int {
+	danger := 0
+	for _, mons := range g.Monsters {
+		if mons.Exists() {
+			danger += mons.Danger()
+		}
+	}
+	return danger
+}
+
+func (g *game) Reinforce() {
+	t := g.ReinforcementTime()
+	if !g.ReinforcementWarned {
+		if t < g.ReinforcementTurn-ReinforcementWarning {
+			return
+		}
+		if g.MaxDanger()-g.LivingDanger() < g.Depth+2 {
+			// the level is still crowded enough
+			g.ReinforcementTurn = t + ReinforcementWarning + g.Opts.Difficulty.ReinforcementDelay()/2
+			return
+		}
+		g.ReinforcementWarned = true
+		g.ReinforcementTurn = Max(g.ReinforcementTurn, t+ReinforcementWarning)
+		g.PrintStyled("You hear distant voices and footsteps: something is coming to this level.", logCritic)
+		g.StopAuto()
+		return
+	}
+	if t < g.ReinforcementTurn {
+		return
+	}
+	g.ReinforcementWarned = false
+	g.ReinforcementTurn = t + g.Opts.Difficulty.ReinforcementDelay()
+	g.GenReinforcements()
+}
+
+func (g *game) GenReinforcements() {
+	budget := g.MaxDanger() - g.LivingDanger()
+	for i := 0; i < 200; i++ {
+		band := RandInt(len(g.BandData))
+		data := g.BandData[band]
+		if data.Unique || RandInt(data.Rarity) != 0 {
+			continue
+		}
+		monsters := g.GenBand(data, monsterBand(band))
+		if len(monsters) == 0 {
+			continue
+		}
+		danger := 0
+		for _, mk := range monsters {
+			danger += mk.Dangerousness()
+		}
+		if danger > budget {
+			continue
+		}
+		pos, stairs := g.ReinforcementEntry()
+		g.Bands = append(g.Bands, monsterBand(band))
+		nband := len(g.Bands) - 1
+		for _, mk := range monsters {
+			if mk == MonsGoblin {
+				mk = g.Opts.Alternate
+			}
+			mons := &monster{Kind: mk}
+			mons.Init()
+			g.AddMonster(mons, nband, pos)
+			mons.State = Wandering
+			mons.Target = g.Player.Pos
+			pos = g.FreeCellForBandMonster(pos)
+		}
+		if stairs {
+			g.PrintStyled("You hear monsters coming down the stairs.", logCritic)
+		} else {
+			g.PrintStyled("You hear monsters arriving from afar.", logCritic)
+		}
+		g.StopAuto()
+		return
+	}
+}
+
+// ReinforcementEntry returns an entry position out of sight for
+// reinforcements: either some stairs or a free cell near the level edges.
+func (g *game) ReinforcementEntry() (position, bool) {
+	free := func(pos position) bool {
+		return !g.Player.LOS[pos] && pos.Distance(g.Player.Pos) >= 10 && !g.MonsterAt(pos).Exists()
+	}
+	if RandInt(2) == 0 {
+		for pos := range g.Stairs {
+			if free(pos) {
+				return pos, true
+			}
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		pos := g.FreeCellForMonster()
+		if !free(pos) {
+			continue
+		}
+		if pos.X < 6 || pos.X >= DungeonWidth-6 || pos.Y < 3 || pos.Y >= DungeonHeight-3 || i > 500 {
+			return pos, false
+		}
+	}
+	return g.FreeCellForMonster(), false
+}
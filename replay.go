@@ -1,9 +1,35 @@
 package main
 
 import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
+// ReplayKeyframeInterval is the number of frames between two full screen
+// snapshots used for seeking.
+const ReplayKeyframeInterval = 200
+
+type frameMark int
+
+const (
+	NoMark frameMark = iota
+	MarkCriticalHP
+	MarkDeath
+)
+
+// MarkFrame marks the last recorded frame, so that the replay viewer can
+// jump to it.
+func (ui *gameui) MarkFrame(mk frameMark) {
+	if n := len(ui.g.DrawLog); n > 0 {
+		ui.g.DrawLog[n-1].Mark = mk
+	}
+}
+
 func (ui *gameui) Replay() {
 	g := ui.g
 	dl := g.DrawLog
@@ -11,22 +37,30 @@ func (ui *gameui) Replay() {
 		return
 	}
 	g.DrawLog = nil
-	rep := &replay{ui: ui, frames: dl, frame: 0}
+	rep := &replay{ui: ui, frames: dl, frame: 0, info: true}
 	if ColorBase03 == Color256Base03 {
 		rep.color256 = true
 	}
+	bms, err := g.LoadBookmarks()
+	if err == nil {
+		rep.bookmarks = DecodeBookmarks(bms, dl[0].Time)
+	}
 	rep.Run()
 }
 
 type replay struct {
-	ui       *gameui
-	frames   []drawFrame
-	undo     [][]cellDraw
-	frame    int
-	auto     bool
-	speed    time.Duration
-	evch     chan repEvent
-	color256 bool
+	ui        *gameui
+	frames    []drawFrame
+	screen    []UICell
+	keyframes [][]UICell
+	bookmarks []int
+	frame     int
+	auto      bool
+	speed     time.Duration
+	evch      chan repEvent
+	color256  bool
+	info      bool
+	status    string
 }
 
 type repEvent int
@@ -38,13 +72,25 @@ const (
 	ReplayQuit
 	ReplaySpeedMore
 	ReplaySpeedLess
+	ReplayForward
+	ReplayBackward
+	ReplayStart
+	ReplayEnd
+	ReplayNextEvent
+	ReplayPreviousEvent
+	ReplayToggleBookmark
+	ReplayNextBookmark
+	ReplayPreviousBookmark
+	ReplayToggleInfo
 )
 
 func (rep *replay) Run() {
 	rep.auto = true
 	rep.speed = 1
 	rep.evch = make(chan repEvent, 100)
-	rep.undo = [][]cellDraw{}
+	rep.screen = make([]UICell, len(rep.ui.g.DrawBuffer))
+	copy(rep.screen, rep.ui.g.DrawBuffer)
+	rep.ComputeKeyframes()
 	go func(r *replay) {
 		r.PollKeyboardEvents()
 	}(rep)
@@ -57,67 +103,280 @@ func (rep *replay) Run() {
 			} else if rep.frame < 0 {
 				rep.frame = 0
 			}
-			rep.DrawFrame()
+			rep.ApplyFrame(rep.screen, rep.frames[rep.frame])
 			rep.frame++
+			rep.Draw()
 		case ReplayPrevious:
 			if rep.frame <= 1 {
 				break
-			} else if rep.frame >= len(rep.frames) {
-				rep.frame = len(rep.frames)
 			}
-			rep.frame--
-			rep.UndoFrame()
+			rep.Seek(rep.frame - 1)
+		case ReplayForward:
+			rep.Seek(rep.frame + len(rep.frames)/10)
+		case ReplayBackward:
+			rep.Seek(rep.frame - len(rep.frames)/10)
+		case ReplayStart:
+			rep.Seek(1)
+		case ReplayEnd:
+			rep.Seek(len(rep.frames))
+		case ReplayNextEvent:
+			rep.NextEvent(true)
+		case ReplayPreviousEvent:
+			rep.NextEvent(false)
+		case ReplayToggleBookmark:
+			rep.ToggleBookmark()
+		case ReplayNextBookmark:
+			rep.NextBookmark(true)
+		case ReplayPreviousBookmark:
+			rep.NextBookmark(false)
+		case ReplayToggleInfo:
+			rep.info = !rep.info
+			rep.Draw()
 		case ReplayQuit:
 			return
 		case ReplayTogglePause:
 			rep.auto = !rep.auto
+			rep.Draw()
 		case ReplaySpeedMore:
 			rep.speed *= 2
 			if rep.speed > 16 {
 				rep.speed = 16
 			}
+			rep.Draw()
 		case ReplaySpeedLess:
 			rep.speed /= 2
 			if rep.speed < 1 {
 				rep.speed = 1
 			}
+			rep.Draw()
 		}
 	}
 }
 
-func (rep *replay) DrawFrame() {
+// ApplyFrame draws the cells of a frame on the screen buffer, adapting
+// colors to the current palette.
+func (rep *replay) ApplyFrame(screen []UICell, df drawFrame) {
 	ui := rep.ui
-	df := rep.frames[rep.frame]
-	rep.undo = append(rep.undo, []cellDraw{})
-	j := len(rep.undo) - 1
 	for _, dr := range df.Draws {
 		i := ui.GetIndex(dr.X, dr.Y)
-		c := ui.g.DrawBuffer[i]
+		if i < 0 || i >= len(screen) {
+			continue
+		}
 		if rep.color256 {
-			dr.Cell.Fg = rep.ui.Map16ColorTo256(dr.Cell.Fg)
-			dr.Cell.Bg = rep.ui.Map16ColorTo256(dr.Cell.Bg)
+			dr.Cell.Fg = ui.Map16ColorTo256(dr.Cell.Fg)
+			dr.Cell.Bg = ui.Map16ColorTo256(dr.Cell.Bg)
 		} else {
 			dr.Cell.Bg = ui.Map256ColorTo16(dr.Cell.Bg)
 			dr.Cell.Fg = ui.Map256ColorTo16(dr.Cell.Fg)
 		}
-		rep.undo[j] = append(rep.undo[j], cellDraw{Cell: c, X: dr.X, Y: dr.Y})
-		ui.SetGenCell(dr.X, dr.Y, dr.Cell.R, dr.Cell.Fg, dr.Cell.Bg, dr.Cell.InMap)
+		screen[i] = dr.Cell
 	}
-	ui.Flush()
-	ui.g.DrawLog = nil
 }
 
-func (rep *replay) UndoFrame() {
+// ComputeKeyframes records the screen before every ReplayKeyframeInterval
+// frames.
+func (rep *replay) ComputeKeyframes() {
+	screen := make([]UICell, len(rep.screen))
+	copy(screen, rep.screen)
+	rep.keyframes = nil
+	for i, df := range rep.frames {
+		if i%ReplayKeyframeInterval == 0 {
+			kf := make([]UICell, len(screen))
+			copy(kf, screen)
+			rep.keyframes = append(rep.keyframes, kf)
+		}
+		rep.ApplyFrame(screen, df)
+	}
+}
+
+// Seek shows the screen as it was after drawing the first n frames.
+func (rep *replay) Seek(n int) {
+	rep.SeekScreen(n)
+	rep.Draw()
+}
+
+// SeekScreen restores the screen as it was after drawing the first n frames,
+// starting from the nearest keyframe.
+func (rep *replay) SeekScreen(n int) {
+	if n < 1 {
+		n = 1
+	}
+	if n > len(rep.frames) {
+		n = len(rep.frames)
+	}
+	k := n / ReplayKeyframeInterval
+	if k >= len(rep.keyframes) {
+		k = len(rep.keyframes) - 1
+	}
+	copy(rep.screen, rep.keyframes[k])
+	for i := k * ReplayKeyframeInterval; i < n; i++ {
+		rep.ApplyFrame(rep.screen, rep.frames[i])
+	}
+	rep.frame = n
+}
+
+func (rep *replay) Draw() {
 	ui := rep.ui
-	df := rep.undo[len(rep.undo)-1]
-	for _, dr := range df {
-		ui.SetGenCell(dr.X, dr.Y, dr.Cell.R, dr.Cell.Fg, dr.Cell.Bg, dr.Cell.InMap)
+	copy(ui.g.DrawBuffer, rep.screen)
+	if rep.info {
+		rep.DrawInfo()
 	}
-	rep.undo = rep.undo[:len(rep.undo)-1]
 	ui.Flush()
 	ui.g.DrawLog = nil
 }
 
+// DrawInfo draws on the last line the current turn and depth, along with a
+// progress bar showing bookmarks.
+func (rep *replay) DrawInfo() {
+	ui := rep.ui
+	y := UIHeight - 1
+	var turn, depth int
+	if rep.frame > 0 {
+		df := rep.frames[rep.frame-1]
+		turn, depth = df.Turn, df.Depth
+	}
+	text := fmt.Sprintf(" turn %d depth %d ", turn, depth)
+	if !rep.auto {
+		text += "(paused) "
+	} else if rep.speed > 1 {
+		text += fmt.Sprintf("(×%d) ", rep.speed)
+	}
+	if rep.status != "" {
+		text += rep.status + " "
+		rep.status = ""
+	}
+	for x := 0; x < UIWidth; x++ {
+		ui.SetCell(x, y, ' ', ColorBase1, ColorBase02)
+	}
+	ui.DrawColoredTextOnBG(text, 0, y, ColorBase1, ColorBase02)
+	col := utf8.RuneCountInString(text)
+	width := UIWidth - col - 1
+	if width < 10 {
+		return
+	}
+	done := width * rep.frame / len(rep.frames)
+	for i := 0; i < width; i++ {
+		r, fg := '─', ColorBase01
+		if i < done {
+			r, fg = '━', ColorYellow
+		}
+		ui.SetCell(col+i, y, r, fg, ColorBase02)
+	}
+	for _, b := range rep.bookmarks {
+		i := width * b / len(rep.frames)
+		if i >= width {
+			i = width - 1
+		}
+		ui.SetCell(col+i, y, '♦', ColorCyan, ColorBase02)
+	}
+}
+
+// FrameEvent returns a description of the notable event of frame i, if
+// any: depth changes, critical HP and death.
+func (rep *replay) FrameEvent(i int) string {
+	df := rep.frames[i]
+	switch df.Mark {
+	case MarkCriticalHP:
+		return "critical HP"
+	case MarkDeath:
+		return "death"
+	}
+	if i > 0 && df.Depth != rep.frames[i-1].Depth {
+		return fmt.Sprintf("depth %d", df.Depth)
+	}
+	return ""
+}
+
+func (rep *replay) NextEvent(forward bool) {
+	if forward {
+		for i := rep.frame; i < len(rep.frames); i++ {
+			if ev := rep.FrameEvent(i); ev != "" {
+				rep.status = ev
+				rep.Seek(i + 1)
+				return
+			}
+		}
+	} else {
+		for i := rep.frame - 2; i >= 0; i-- {
+			if ev := rep.FrameEvent(i); ev != "" {
+				rep.status = ev
+				rep.Seek(i + 1)
+				return
+			}
+		}
+	}
+	rep.status = "no more events"
+	rep.Draw()
+}
+
+func (rep *replay) ToggleBookmark() {
+	if rep.frame < 1 {
+		return
+	}
+	i := sort.SearchInts(rep.bookmarks, rep.frame)
+	if i < len(rep.bookmarks) && rep.bookmarks[i] == rep.frame {
+		rep.bookmarks = append(rep.bookmarks[:i], rep.bookmarks[i+1:]...)
+		rep.status = "bookmark removed"
+	} else {
+		rep.bookmarks = append(rep.bookmarks, 0)
+		copy(rep.bookmarks[i+1:], rep.bookmarks[i:])
+		rep.bookmarks[i] = rep.frame
+		rep.status = "bookmark added"
+	}
+	err := rep.ui.g.SaveBookmarks(EncodeBookmarks(rep.bookmarks, rep.frames[0].Time))
+	if err != nil {
+		rep.status = fmt.Sprintf("error saving bookmarks: %v", err)
+	}
+	rep.Draw()
+}
+
+func (rep *replay) NextBookmark(forward bool) {
+	if forward {
+		i := sort.SearchInts(rep.bookmarks, rep.frame+1)
+		if i < len(rep.bookmarks) {
+			rep.Seek(rep.bookmarks[i])
+			return
+		}
+	} else {
+		i := sort.SearchInts(rep.bookmarks, rep.frame)
+		if i > 0 {
+			rep.Seek(rep.bookmarks[i-1])
+			return
+		}
+	}
+	rep.status = "no more bookmarks"
+	rep.Draw()
+}
+
+// EncodeBookmarks returns the bookmarks as text, one frame number per line,
+// after a line identifying the replay by its start time.
+func EncodeBookmarks(bms []int, start time.Time) []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "start %d\n", start.UnixNano())
+	for _, b := range bms {
+		fmt.Fprintf(buf, "%d\n", b)
+	}
+	return buf.Bytes()
+}
+
+// DecodeBookmarks returns the bookmarks encoded in data, or nothing if they
+// belong to another replay.
+func DecodeBookmarks(data []byte, start time.Time) []int {
+	lines := strings.Split(string(data), "\n")
+	if lines[0] != fmt.Sprintf("start %d", start.UnixNano()) {
+		return nil
+	}
+	bms := []int{}
+	for _, l := range lines[1:] {
+		b, err := strconv.Atoi(strings.TrimSpace(l))
+		if err == nil && b > 0 {
+			bms = append(bms, b)
+		}
+	}
+	sort.Ints(bms)
+	return bms
+}
+
 func (rep *replay) PollEvent() (in repEvent) {
 	if rep.auto && rep.frame <= len(rep.frames)-1 && rep.frame >= 0 {
 		var d time.Duration
@@ -167,6 +426,26 @@ func (rep *replay) PollKeyboardEvents() {
 			rep.evch <- ReplayNext
 		case "4", "k", "N", "b":
 			rep.evch <- ReplayPrevious
+		case "2", "J":
+			rep.evch <- ReplayForward
+		case "8", "K":
+			rep.evch <- ReplayBackward
+		case "g":
+			rep.evch <- ReplayStart
+		case "G":
+			rep.evch <- ReplayEnd
+		case "e":
+			rep.evch <- ReplayNextEvent
+		case "E":
+			rep.evch <- ReplayPreviousEvent
+		case "m", "M":
+			rep.evch <- ReplayToggleBookmark
+		case "]":
+			rep.evch <- ReplayNextBookmark
+		case "[":
+			rep.evch <- ReplayPreviousBookmark
+		case "i", "I":
+			rep.evch <- ReplayToggleInfo
 		default:
 			if !e.mouse {
 				break
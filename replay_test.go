@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBookmarks(t *testing.T) {
+	start := time.Unix(1500000000, 42)
+	type tableTest struct {
+		bms []int
+	}
+	table := []tableTest{
+		{[]int{}},
+		{[]int{1}},
+		{[]int{3, 250, 251, 1000}},
+	}
+	for _, test := range table {
+		data := EncodeBookmarks(test.bms, start)
+		bms := DecodeBookmarks(data, start)
+		if !reflect.DeepEqual(bms, test.bms) {
+			t.Errorf("Bad bookmarks: %v instead of %v", bms, test.bms)
+		}
+		if bms := DecodeBookmarks(data, start.Add(time.Second)); bms != nil {
+			t.Errorf("Bookmarks of another replay: %v", bms)
+		}
+	}
+	bms := DecodeBookmarks([]byte("start 1500000000000000042\n20\nfoo\n-1\n5\n"), start)
+	if !reflect.DeepEqual(bms, []int{5, 20}) {
+		t.Errorf("Bad decoded bookmarks: %v", bms)
+	}
+}
+
+func TestReplaySeek(t *testing.T) {
+	g := &game{}
+	ui := &gameui{g: g}
+	g.ui = ui
+	frames := []drawFrame{}
+	for i := 0; i < 3*ReplayKeyframeInterval+17; i++ {
+		df := drawFrame{}
+		for j := 0; j < 1+RandInt(5); j++ {
+			c := UICell{R: rune('a' + RandInt(26)), Fg: uicolor(RandInt(16)), Bg: uicolor(RandInt(16))}
+			df.Draws = append(df.Draws, cellDraw{Cell: c, X: RandInt(UIWidth), Y: RandInt(UIHeight)})
+		}
+		frames = append(frames, df)
+	}
+	rep := &replay{ui: ui, frames: frames, screen: make([]UICell, UIWidth*UIHeight)}
+	rep.ComputeKeyframes()
+	for _, n := range []int{1, 2, ReplayKeyframeInterval - 1, ReplayKeyframeInterval, ReplayKeyframeInterval + 1,
+		2*ReplayKeyframeInterval + 50, len(frames) - 1, len(frames)} {
+		screen := make([]UICell, UIWidth*UIHeight)
+		for _, df := range frames[:n] {
+			rep.ApplyFrame(screen, df)
+		}
+		rep.SeekScreen(n)
+		if rep.frame != n {
+			t.Errorf("Bad frame after seeking %d: %d", n, rep.frame)
+		}
+		if !reflect.DeepEqual(rep.screen, screen) {
+			t.Errorf("Seeking frame %d differs from sequential replay", n)
+		}
+	}
+}
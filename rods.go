@@ -96,11 +96,133 @@ func (r rod) Desc() string {
 	case RodSwapping:
 		text = "makes you swap positions with a targeted monster."
 	}
-	return fmt.Sprintf("The %s %s Rods sometimes regain charges as you go deeper. This rod can have up to %d charges.", r, text, r.MaxCharge())
+	return fmt.Sprintf("The %s %s Rods sometimes regain charges as you go deeper. This rod can have up to %d charges. Rods can be enhanced by sacrificing a duplicate rod or by draining a magical stone.", r, text, r.MaxCharge())
 }
 
 type rodProps struct {
-	Charge int
+	Charge   int
+	Charges  int
+	Recharge int
+	Mana     int
+}
+
+func (rp rodProps) Level() int {
+	return rp.Charges + rp.Recharge + rp.Mana
+}
+
+const MaxRodLevel = 3
+
+type rodUpgrade int
+
+const (
+	RodUpgradeCharges rodUpgrade = iota
+	RodUpgradeRecharge
+	RodUpgradeMana
+)
+
+var RodUpgrades = []rodUpgrade{RodUpgradeCharges, RodUpgradeRecharge, RodUpgradeMana}
+
+func (u rodUpgrade) String() (text string) {
+	switch u {
+	case RodUpgradeCharges:
+		text = "one more maximum charge"
+	case RodUpgradeRecharge:
+		text = "faster recharge"
+	case RodUpgradeMana:
+		text = "reduced mana cost"
+	}
+	return text
+}
+
+func (g *game) RodMaxCharge(r rod) int {
+	mc := r.MaxCharge() + g.Player.Rods[r].Charges
+	if g.Player.Armour == CelmistRobe {
+		mc += 2
+	}
+	return mc
+}
+
+func (g *game) RodMPCost(r rod) int {
+	mp := r.MPCost() - g.Player.Rods[r].Mana
+	if mp < 0 {
+		mp = 0
+	}
+	return mp
+}
+
+func (g *game) CanUpgradeRod(r rod, u rodUpgrade) error {
+	props, ok := g.Player.Rods[r]
+	if !ok {
+		// should not happen
+		return errors.New("You do not have such a rod.")
+	}
+	if props.Level() >= MaxRodLevel {
+		return fmt.Errorf("Your %s cannot be enhanced any further.", r)
+	}
+	if u == RodUpgradeMana && g.RodMPCost(r) == 0 {
+		return fmt.Errorf("Your %s does not cost any mana already.", r)
+	}
+	return nil
+}
+
+func (g *game) UpgradeRod(r rod, u rodUpgrade) {
+	props := g.Player.Rods[r]
+	switch u {
+	case RodUpgradeCharges:
+		props.Charges++
+		props.Charge++
+	case RodUpgradeRecharge:
+		props.Recharge++
+	case RodUpgradeMana:
+		props.Mana++
+	}
+	g.Player.Rods[r] = props
+	g.Stats.RodUpgrades++
+}
+
+func (g *game) EnhanceRod(ev event) error {
+	pos := g.Player.Pos
+	if r, ok := g.Rods[pos]; ok {
+		if _, ok := g.Player.Rods[r]; !ok {
+			// should not happen
+			return errors.New("Found nothing to enhance your rods with here.")
+		}
+		u, err := g.ui.SelectRodUpgrade(r)
+		if err != nil {
+			return err
+		}
+		if err := g.CanUpgradeRod(r, u); err != nil {
+			return err
+		}
+		g.UpgradeRod(r, u)
+		delete(g.Rods, pos)
+		g.DijkstraMapRebuild = true
+		g.Printf("You sacrifice the duplicate %s. Your rod gains %s.", r, u)
+		g.StoryPrintf("Sacrificed a duplicate %s (%s).", r, u)
+	} else if stn, ok := g.MagicalStones[pos]; ok && stn != InertStone {
+		if len(g.Player.Rods) == 0 {
+			return errors.New("You do not have any rods to enhance.")
+		}
+		r, err := g.ui.SelectRodToEnhance()
+		if err != nil {
+			return err
+		}
+		u, err := g.ui.SelectRodUpgrade(r)
+		if err != nil {
+			return err
+		}
+		if err := g.CanUpgradeRod(r, u); err != nil {
+			return err
+		}
+		g.UpgradeRod(r, u)
+		g.MagicalStones[pos] = InertStone
+		g.Printf("You drain the %s. Your %s gains %s. The stone becomes inert.", stn, r, u)
+		g.StoryPrintf("Drained a %s to enhance the %s (%s).", stn, r, u)
+	} else {
+		return errors.New("Found nothing to enhance your rods with here.")
+	}
+	ev.Renew(g, 10)
+	return nil
 }
 
 func (r rod) MaxCharge() (charges int) {
@@ -141,10 +263,10 @@ func (r rod) Use(g *game, ev event) error {
 	if rods[r].Charge <= 0 {
 		return errors.New("No charges remaining on this rod.")
 	}
-	if r.MPCost() > g.Player.MP {
+	if g.RodMPCost(r) > g.Player.MP {
 		return errors.New("Not enough magic points for using this rod.")
 	}
-	if g.Player.HasStatus(StatusBerserk) {
+	if g.Player.HasEffect(EffBerserk) {
 		return errors.New("You cannot use rods while berserk.")
 	}
 	var err error
@@ -183,7 +305,7 @@ func (r rod) Use(g *game, ev event) error {
 	rp := rods[r]
 	rp.Charge--
 	rods[r] = rp
-	g.Player.MP -= r.MPCost()
+	g.Player.MP -= g.RodMPCost(r)
 	g.StoryPrintf("Evoked your %s.", r)
 	g.Stats.UsedRod[r]++
 	g.Stats.Evocations++
@@ -193,7 +315,7 @@ func (r rod) Use(g *game, ev event) error {
 }
 
 func (g *game) EvokeRodBlink(ev event) error {
-	if g.Player.HasStatus(StatusLignification) {
+	if g.Player.HasEffect(EffLignification) {
 		return errors.New("You cannot blink while lignified.")
 	}
 	g.Blink(ev)
@@ -229,7 +351,7 @@ func (g *game) BlinkPos() position {
 }
 
 func (g *game) Blink(ev event) {
-	if g.Player.HasStatus(StatusLignification) {
+	if g.Player.HasEffect(EffLignification) {
 		return
 	}
 	npos := g.BlinkPos()
@@ -404,6 +526,9 @@ const (
 	CloudFog cloud = iota
 	CloudFire
 	CloudNight
+	CloudPoison
+	CloudSteam
+	CloudConfusion
 )
 
 func (g *game) EvokeRodFog(ev event) error {
@@ -500,7 +625,7 @@ func (g *game) EvokeRodLignification(ev event) error {
 	}
 	mons := g.MonsterAt(g.Player.Target)
 	// mons not nil (check done in targeter)
-	if mons.Status(MonsLignified) {
+	if mons.HasEffect(EffLignification) {
 		return errors.New("You cannot target a lignified monster.")
 	}
 	mons.EnterLignification(g, ev)
@@ -520,7 +645,7 @@ func (g *game) TemporalWallAt(pos position, ev event) {
 
 func (g *game) CreateTemporalWallAt(pos position, ev event) {
 	g.Dungeon.SetCell(pos, WallCell)
-	delete(g.Clouds, pos)
+	g.PushCloudAway(pos, ev)
 	g.TemporalWalls[pos] = true
 	g.PushEvent(&cloudEvent{ERank: ev.Rank() + 200 + RandInt(50), Pos: pos, EAction: ObstructionEnd})
 }
@@ -558,7 +683,7 @@ func (g *game) EvokeRodHope(ev event) error {
 }
 
 func (g *game) EvokeRodSwapping(ev event) error {
-	if g.Player.HasStatus(StatusLignification) {
+	if g.Player.HasEffect(EffLignification) {
 		return errors.New("You cannot use this rod while lignified.")
 	}
 	if err := g.ui.ChooseTarget(&chooser{}); err != nil {
@@ -566,7 +691,7 @@ func (g *game) EvokeRodSwapping(ev event) error {
 	}
 	mons := g.MonsterAt(g.Player.Target)
 	// mons not nil (check done in the targeter)
-	if mons.Status(MonsLignified) {
+	if mons.HasEffect(EffLignification) {
 		return errors.New("You cannot target a lignified monster.")
 	}
 	g.SwapWithMonster(mons)
@@ -606,7 +731,7 @@ func (g *game) GenerateRod() {
 		}
 		pos := g.FreeCellForStatic()
 		r := g.RandomRod()
-		if _, ok := g.Player.Rods[r]; !ok && !g.GeneratedRods[r] {
+		if _, ok := g.Player.Rods[r]; (!ok || RandInt(3) == 0) && !g.GeneratedRods[r] {
 			g.GeneratedRods[r] = true
 			g.Rods[pos] = r
 			return
@@ -616,12 +741,9 @@ func (g *game) GenerateRod() {
 
 func (g *game) RechargeRods() {
 	for r, props := range g.Player.Rods {
-		max := r.MaxCharge()
-		if g.Player.Armour == CelmistRobe {
-			max += 2
-		}
+		max := g.RodMaxCharge(r)
 		if props.Charge < max {
-			rchg := RandInt(1 + r.Rate())
+			rchg := RandInt(1+r.Rate()) + props.Recharge
 			if rchg == 0 && RandInt(2) == 0 {
 				rchg++
 			}
@@ -633,6 +755,10 @@ func (g *game) RechargeRods() {
 					rchg++
 				}
 			}
+			rchg += g.Opts.Difficulty.RechargeAdjust()
+			if rchg < 0 {
+				rchg = 0
+			}
 			props.Charge += rchg
 			g.Player.Rods[r] = props
 		}
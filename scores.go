@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// scoreEntry records the outcome of a finished game.
+type scoreEntry struct {
+	Background background
+	Difficulty difficulty
+	Depth      int
+	Win        bool
+	Simellas   int
+	Killed     int
+	Turns      int
+}
+
+// scoreTable records the outcomes of finished games, so that progress can be
+// tracked per background and difficulty.
+type scoreTable struct {
+	Entries []scoreEntry
+}
+
+type scoreSummary struct {
+	Games    int
+	Wins     int
+	Depth    int
+	Simellas int
+}
+
+func (g *game) NewScoreEntry(win bool) scoreEntry {
+	return scoreEntry{
+		Background: g.Player.Background,
+		Difficulty: g.Opts.Difficulty,
+		Depth:      Max(g.Depth, g.ExploredLevels),
+		Win:        win,
+		Simellas:   g.Player.Simellas,
+		Killed:     g.Stats.Killed,
+		Turns:      g.Turn / 10,
+	}
+}
+
+func (st *scoreTable) Summary(bg background, d difficulty) scoreSummary {
+	sum := scoreSummary{}
+	for _, e := range st.Entries {
+		if e.Background != bg || e.Difficulty != d {
+			continue
+		}
+		sum.Games++
+		if e.Win {
+			sum.Wins++
+		}
+		if e.Depth > sum.Depth {
+			sum.Depth = e.Depth
+		}
+		if e.Simellas > sum.Simellas {
+			sum.Simellas = e.Simellas
+		}
+	}
+	return sum
+}
+
+func (sum scoreSummary) String() string {
+	games := "games"
+	if sum.Games == 1 {
+		games = "game"
+	}
+	wins := "wins"
+	if sum.Wins == 1 {
+		wins = "win"
+	}
+	return fmt.Sprintf("%d %s, %d %s, deepest level %d, best harvest %d simellas",
+		sum.Games, games, sum.Wins, wins, sum.Depth, sum.Simellas)
+}
+
+func (st *scoreTable) String() string {
+	if len(st.Entries) == 0 {
+		return "No finished games yet.\n"
+	}
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, " ♣ Boohu score table ♣\n\n")
+	for _, bg := range Backgrounds {
+		for _, d := range Difficulties {
+			sum := st.Summary(bg, d)
+			if sum.Games == 0 {
+				continue
+			}
+			fmt.Fprintf(buf, "%-19s %s\n", fmt.Sprintf("%s (%s):", bg, d), sum)
+		}
+	}
+	return buf.String()
+}
+
+// DumpScore returns a summary of previous games with the same background and
+// difficulty.
+func (g *game) DumpScore() string {
+	if g.scores == nil {
+		return ""
+	}
+	sum := g.scores.Summary(g.Player.Background, g.Opts.Difficulty)
+	return fmt.Sprintf("As %s (%s): %s.\n", Indefinite(g.Player.Background.String(), false), g.Opts.Difficulty, sum)
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestScoreSummary(t *testing.T) {
+	st := &scoreTable{Entries: []scoreEntry{
+		{Background: BgBrawler, Depth: 4, Simellas: 20},
+		{Background: BgBrawler, Difficulty: HardDifficulty, Depth: 2, Simellas: 40},
+		{Background: BgBrawler, Depth: 11, Win: true, Simellas: 15},
+		{Background: BgEvoker, Depth: 7, Simellas: 30},
+	}}
+	sum := st.Summary(BgBrawler, NormalDifficulty)
+	if sum.Games != 2 || sum.Wins != 1 || sum.Depth != 11 || sum.Simellas != 20 {
+		t.Errorf("Bad brawler summary: %+v", sum)
+	}
+	sum = st.Summary(BgBrawler, HardDifficulty)
+	if sum.Games != 1 || sum.Depth != 2 {
+		t.Errorf("Bad hard brawler summary: %+v", sum)
+	}
+	data, err := st.ScoresSave()
+	if err != nil {
+		t.Fatalf("Scores encoding: %v", err)
+	}
+	g := &game{}
+	lst, err := g.DecodeScores(data)
+	if err != nil {
+		t.Fatalf("Scores decoding: %v", err)
+	}
+	if lst.Summary(BgEvoker, NormalDifficulty) != st.Summary(BgEvoker, NormalDifficulty) {
+		t.Errorf("Bad decoded evoker summary: %+v", lst.Summary(BgEvoker, NormalDifficulty))
+	}
+}
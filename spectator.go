@@ -0,0 +1,203 @@
+// +build !js
+
+package main
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpectatorQueue is the number of frames buffered for each spectator. Slower
+// spectators are disconnected.
+const SpectatorQueue = 1024
+
+// spectatorAddr returns the network and address of a spectator socket:
+// addresses starting with "unix:" are unix socket paths, others are TCP
+// addresses.
+func spectatorAddr(addr string) (network, address string) {
+	if strings.HasPrefix(addr, "unix:") {
+		return "unix", strings.TrimPrefix(addr, "unix:")
+	}
+	return "tcp", addr
+}
+
+// spectatorServer streams the frames of a live game to any number of
+// spectators.
+type spectatorServer struct {
+	ln      net.Listener
+	mu      sync.Mutex
+	clients map[*spectator]bool
+	screen  map[position]UICell
+}
+
+type spectator struct {
+	conn   net.Conn
+	frames chan drawFrame
+}
+
+func ServeSpectators(addr string) (*spectatorServer, error) {
+	network, address := spectatorAddr(addr)
+	if network == "unix" {
+		// remove a stale socket from a previous game
+		if fi, err := os.Stat(address); err == nil && fi.Mode()&os.ModeSocket != 0 {
+			os.Remove(address)
+		}
+	}
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	srv := &spectatorServer{ln: ln, clients: map[*spectator]bool{}, screen: map[position]UICell{}}
+	go srv.Accept()
+	return srv, nil
+}
+
+func (srv *spectatorServer) Accept() {
+	for {
+		conn, err := srv.ln.Accept()
+		if err != nil {
+			return
+		}
+		sp := &spectator{conn: conn, frames: make(chan drawFrame, SpectatorQueue)}
+		srv.mu.Lock()
+		sp.frames <- srv.Keyframe()
+		srv.clients[sp] = true
+		srv.mu.Unlock()
+		go srv.Stream(sp)
+	}
+}
+
+// Keyframe returns a frame drawing the whole current screen, so that late
+// spectators do not start with a partial screen.
+func (srv *spectatorServer) Keyframe() drawFrame {
+	df := drawFrame{Time: time.Now()}
+	for pos, c := range srv.screen {
+		df.Draws = append(df.Draws, cellDraw{Cell: c, X: pos.X, Y: pos.Y})
+	}
+	return df
+}
+
+func (srv *spectatorServer) Stream(sp *spectator) {
+	enc := gob.NewEncoder(sp.conn)
+	for df := range sp.frames {
+		err := enc.Encode(&df)
+		if err != nil {
+			srv.Drop(sp)
+			return
+		}
+	}
+}
+
+func (srv *spectatorServer) Drop(sp *spectator) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.clients[sp] {
+		delete(srv.clients, sp)
+		close(sp.frames)
+		sp.conn.Close()
+	}
+}
+
+// Broadcast sends a new frame to all spectators.
+func (srv *spectatorServer) Broadcast(df drawFrame) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	for _, dr := range df.Draws {
+		srv.screen[position{X: dr.X, Y: dr.Y}] = dr.Cell
+	}
+	for sp := range srv.clients {
+		select {
+		case sp.frames <- df:
+		default:
+			delete(srv.clients, sp)
+			close(sp.frames)
+			sp.conn.Close()
+		}
+	}
+}
+
+func (srv *spectatorServer) Close() {
+	srv.ln.Close()
+	srv.mu.Lock()
+	for sp := range srv.clients {
+		delete(srv.clients, sp)
+		close(sp.frames)
+		sp.conn.Close()
+	}
+	srv.mu.Unlock()
+}
+
+// Watch shows the game served at addr by another boohu process.
+func Watch(addr string) error {
+	network, address := spectatorAddr(addr)
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var endMsg string
+	defer func() {
+		// printed after the screen is restored
+		if endMsg != "" {
+			fmt.Println(endMsg)
+		}
+	}()
+	ui := &gameui{}
+	g := &game{}
+	ui.g = g
+	g.ui = ui
+	err = ui.Init()
+	if err != nil {
+		return err
+	}
+	defer ui.Close()
+	ui.DrawBufferInit()
+	rep := &replay{ui: ui, color256: ColorBase03 == Color256Base03}
+	rep.screen = make([]UICell, len(g.DrawBuffer))
+	frames := make(chan drawFrame, SpectatorQueue)
+	errc := make(chan error, 1)
+	go func() {
+		dec := gob.NewDecoder(conn)
+		for {
+			var df drawFrame
+			err := dec.Decode(&df)
+			if err != nil {
+				errc <- err
+				return
+			}
+			frames <- df
+		}
+	}()
+	quit := make(chan bool)
+	go func() {
+		for {
+			in := ui.PollEvent()
+			switch in.key {
+			case "Q", "q", "\x1b":
+				quit <- true
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case df := <-frames:
+			rep.ApplyFrame(rep.screen, df)
+			rep.Draw()
+		case err := <-errc:
+			if err == io.EOF {
+				endMsg = "The game has ended."
+				return nil
+			}
+			return fmt.Errorf("connection lost: %v", err)
+		case <-quit:
+			return nil
+		}
+	}
+}
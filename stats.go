@@ -1,34 +1,36 @@
 package main
 
 type stats struct {
-	Story         []string
-	Killed        int
-	KilledMons    map[monsterKind]int
-	Moves         int
-	Hits          int
-	Misses        int
-	ReceivedHits  int
-	Dodges        int
-	Blocks        int
-	Drinks        int
-	Evocations    int
-	UsedStones    int
-	Throws        int
-	TimesLucky    int
-	Damage        int
-	DExplPerc     [MaxDepth + 1]int
-	DSleepingPerc [MaxDepth + 1]int
-	DKilledPerc   [MaxDepth + 1]int
-	DLayout       [MaxDepth + 1]string
-	Burns         int
-	Digs          int
-	Rest          int
-	RestInterrupt int
-	Turns         int
-	TWounded      int
-	TMWounded     int
-	TMonsLOS      int
-	UsedRod       [NumRods]int
+	Story           []string
+	Killed          int
+	KilledMons      map[monsterKind]int
+	KilledChampions map[monsterKind]int
+	Moves           int
+	Hits            int
+	Misses          int
+	ReceivedHits    int
+	Dodges          int
+	Blocks          int
+	Drinks          int
+	Evocations      int
+	UsedStones      int
+	RodUpgrades     int
+	Throws          int
+	TimesLucky      int
+	Damage          int
+	DExplPerc       [MaxDepth + 1]int
+	DSleepingPerc   [MaxDepth + 1]int
+	DKilledPerc     [MaxDepth + 1]int
+	DLayout         [MaxDepth + 1]string
+	Burns           int
+	Digs            int
+	Rest            int
+	RestInterrupt   int
+	Turns           int
+	TWounded        int
+	TMWounded       int
+	TMonsLOS        int
+	UsedRod         [NumRods]int
 }
 
 func (g *game) TurnStats() {
@@ -30,7 +30,7 @@ func (ex *examiner) Action(g *game, pos position) error {
 	if !g.Dungeon.Cell(pos).Explored {
 		return errors.New("You do not know this place.")
 	}
-	if g.Dungeon.Cell(pos).T == WallCell && !g.Player.HasStatus(StatusDig) {
+	if g.Dungeon.Cell(pos).T == WallCell && !g.Player.HasEffect(EffDig) {
 		return errors.New("You cannot travel into a wall.")
 	}
 	path := g.PlayerPath(g.Player.Pos, pos)
@@ -65,6 +65,7 @@ type chooser struct {
 	free         bool
 	flammable    bool
 	wall         bool
+	adjacent     bool
 }
 
 func (ch *chooser) ComputeHighlight(g *game, pos position) {
@@ -89,6 +90,9 @@ func (ch *chooser) Action(g *game, pos position) error {
 	if ch.minDist && pos.Distance(g.Player.Pos) <= 1 {
 		return errors.New("Invalid target: too close.")
 	}
+	if ch.adjacent && pos.Distance(g.Player.Pos) != 1 {
+		return errors.New("Invalid target: not adjacent to you.")
+	}
 	c := g.Dungeon.Cell(pos)
 	if c.T == WallCell {
 		return errors.New("You cannot target a wall.")
@@ -97,6 +101,10 @@ func (ch *chooser) Action(g *game, pos position) error {
 		return errors.New("Invalid target: there are monsters in the way.")
 	}
 	mons := g.MonsterAt(pos)
+	if ch.adjacent && !mons.Visible(g) {
+		// do not reveal invisible monsters
+		return errors.New("You must target a monster.")
+	}
 	if ch.free {
 		if mons.Exists() {
 			return errors.New("Invalid target: there is a monster there.")
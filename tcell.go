@@ -13,6 +13,8 @@ type gameui struct {
 	tcell.Screen
 	cursor position
 	small  bool
+	// textInput is set while reading a line of text
+	textInput bool
 	// below unused for this backend
 	menuHover menu
 	itemHover int
@@ -116,6 +118,12 @@ func (ui *gameui) PollEvent() (in uiInput) {
 			in.key = "3"
 		case tcell.KeyDelete:
 			in.key = "5"
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			in.key = "BackSpace"
+		case tcell.KeyEnter:
+			if ui.textInput {
+				in.key = "Enter"
+			}
 		case tcell.KeyCtrlW:
 			in.key = "W"
 		case tcell.KeyCtrlQ:
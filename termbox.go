@@ -10,6 +10,8 @@ type gameui struct {
 	g      *game
 	cursor position
 	small  bool
+	// textInput is set while reading a line of text
+	textInput bool
 	// below unused for this backend
 	menuHover menu
 	itemHover int
@@ -104,7 +106,13 @@ func (ui *gameui) PollEvent() (in uiInput) {
 			case termbox.KeyEsc, termbox.KeySpace:
 				in.key = " "
 			case termbox.KeyEnter:
-				in.key = "."
+				if ui.textInput {
+					in.key = "Enter"
+				} else {
+					in.key = "."
+				}
+			case termbox.KeyBackspace, termbox.KeyBackspace2:
+				in.key = "BackSpace"
 			}
 		}
 		if tev.Ch != 0 && in.key == "" {
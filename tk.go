@@ -25,6 +25,7 @@ type gameui struct {
 	menuHover menu
 	itemHover int
 	canvas    *image.RGBA
+	textInput bool // set while reading a line of text
 }
 
 func (ui *gameui) Init() error {
@@ -230,12 +231,20 @@ func (ui *gameui) PollEvent() (in uiInput) {
 	}
 	switch in.key {
 	case "KP_Enter", "Return", "\r", "\n":
-		in.key = "."
+		if ui.textInput {
+			in.key = "Enter"
+		} else {
+			in.key = "."
+		}
 	case "Left", "KP_Left":
 		in.key = "4"
 	case "Right", "KP_Right":
 		in.key = "6"
-	case "Up", "KP_Up", "BackSpace":
+	case "BackSpace":
+		if !ui.textInput {
+			in.key = "8"
+		}
+	case "Up", "KP_Up":
 		in.key = "8"
 	case "Down", "KP_Down":
 		in.key = "2"
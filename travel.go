@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+type landmarkKind int
+
+const (
+	LandmarkStairs landmarkKind = iota
+	LandmarkEquipment
+	LandmarkRod
+	LandmarkItem
+	LandmarkStone
+	LandmarkNote
+)
+
+func (lk landmarkKind) String() (text string) {
+	switch lk {
+	case LandmarkStairs:
+		text = "stairs"
+	case LandmarkEquipment:
+		text = "equipment"
+	case LandmarkRod:
+		text = "rod"
+	case LandmarkItem:
+		text = "item"
+	case LandmarkStone:
+		text = "stone"
+	case LandmarkNote:
+		text = "note"
+	}
+	return text
+}
+
+type landmark struct {
+	Kind landmarkKind
+	Pos  position
+	Name string
+	Dist int
+}
+
+type landmarkSlice []landmark
+
+func (ls landmarkSlice) Len() int      { return len(ls) }
+func (ls landmarkSlice) Swap(i, j int) { ls[i], ls[j] = ls[j], ls[i] }
+func (ls landmarkSlice) Less(i, j int) bool {
+	if ls[i].Kind != ls[j].Kind {
+		return ls[i].Kind < ls[j].Kind
+	}
+	return ls[i].Dist < ls[j].Dist
+}
+
+// Landmarks returns the remembered landmarks of the level reachable by the
+// player, grouped by kind and sorted by path distance.
+func (g *game) Landmarks() []landmark {
+	ls := landmarkSlice{}
+	add := func(lk landmarkKind, pos position, name string) {
+		if !g.Dungeon.Cell(pos).Explored || pos == g.Player.Pos {
+			return
+		}
+		path := g.PlayerPath(g.Player.Pos, pos)
+		if len(path) == 0 {
+			return
+		}
+		ls = append(ls, landmark{Kind: lk, Pos: pos, Name: name, Dist: len(path) - 1})
+	}
+	for pos, st := range g.Stairs {
+		if st == WinStair {
+			add(LandmarkStairs, pos, "glowing monolith")
+		} else {
+			add(LandmarkStairs, pos, "stairs downwards")
+		}
+	}
+	for pos, eq := range g.Equipables {
+		add(LandmarkEquipment, pos, Indefinite(eq.String(), false))
+	}
+	for pos, r := range g.Rods {
+		add(LandmarkRod, pos, Indefinite(r.String(), false))
+	}
+	for pos, c := range g.Collectables {
+		add(LandmarkItem, pos, c.Text())
+	}
+	for pos, stn := range g.MagicalStones {
+		if stn != InertStone {
+			add(LandmarkStone, pos, Indefinite(stn.String(), false))
+		}
+	}
+	for pos, note := range g.Annotations {
+		add(LandmarkNote, pos, note)
+	}
+	sort.Sort(ls)
+	return ls
+}
+
+func (g *game) TravelTo(l landmark, ev event) error {
+	ex := &examiner{}
+	err := ex.Action(g, l.Pos)
+	if err == nil && !g.MoveToTarget(ev) {
+		err = errors.New("You could not move toward your destination.")
+	}
+	g.Targeting = InvalidPos
+	return err
+}
+
+func (ui *gameui) LandmarkItem(i, lnum int, l landmark, fg uicolor) {
+	bg := ui.ListItemBG(i)
+	ui.ClearLineWithColor(lnum, bg)
+	ui.DrawColoredTextOnBG(fmt.Sprintf("%c - %-10s %s (%d steps)", rune(i+97), l.Kind, l.Name, l.Dist), 0, lnum, fg, bg)
+}
+
+func (ui *gameui) SelectLandmark(ev event) error {
+	g := ui.g
+	ls := g.Landmarks()
+	if len(ls) == 0 {
+		return errors.New("You do not know any reachable landmarks.")
+	}
+	if len(ls) > DungeonHeight-1 {
+		// keep the nearest ones
+		sort.Slice(ls, func(i, j int) bool { return ls[i].Dist < ls[j].Dist })
+		ls = ls[:DungeonHeight-1]
+		sort.Sort(landmarkSlice(ls))
+	}
+	ui.ClearLine(0)
+	ui.DrawColoredText("Travel", 0, 0, ColorCyan)
+	col := utf8.RuneCountInString("Travel")
+	ui.DrawText(" to which landmark?", col, 0)
+	for i, l := range ls {
+		ui.LandmarkItem(i, i+1, l, ColorFg)
+	}
+	ui.DrawTextLine(" press (x) to cancel ", len(ls)+1)
+	ui.Flush()
+	for {
+		index, alt, err := ui.Select(len(ls))
+		if alt {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		ui.LandmarkItem(index, index+1, ls[index], ColorYellow)
+		ui.Flush()
+		time.Sleep(75 * time.Millisecond)
+		return g.TravelTo(ls[index], ev)
+	}
+}
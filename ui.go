@@ -219,7 +219,10 @@ func (ui *gameui) PlayerTurnEvent(ev event) (err error, again, quit bool) {
 }
 
 func (ui *gameui) Scroll(n int) (m int, quit bool) {
-	in := ui.PollEvent()
+	return ui.ScrollEvent(ui.PollEvent(), n)
+}
+
+func (ui *gameui) ScrollEvent(in uiInput, n int) (m int, quit bool) {
 	switch in.key {
 	case "Escape", "\x1b", " ", "x", "X":
 		quit = true
@@ -569,6 +572,11 @@ const (
 	KeyMenuCommandHelp
 	KeyMenuTargetingHelp
 	KeyInventory
+	KeyCloseDoor
+	KeyShove
+	KeyAutoFight
+	KeyTravel
+	KeyAnnotate
 )
 
 var configurableKeyActions = [...]keyAction{
@@ -611,6 +619,11 @@ var configurableKeyActions = [...]keyAction{
 	KeyTarget,
 	KeyExclude,
 	KeyInventory,
+	KeyCloseDoor,
+	KeyShove,
+	KeyAutoFight,
+	KeyTravel,
+	KeyAnnotate,
 }
 
 var CustomKeys bool
@@ -651,7 +664,11 @@ func (k keyAction) NormalModeKey() bool {
 		KeyConfigure,
 		KeyWizard,
 		KeyWizardInfo,
-		KeyInventory:
+		KeyInventory,
+		KeyCloseDoor,
+		KeyShove,
+		KeyAutoFight,
+		KeyTravel:
 		return true
 	default:
 		return false
@@ -738,6 +755,14 @@ func (k keyAction) NormalModeDescription() (text string) {
 		text = "Action Menu"
 	case KeyInventory:
 		text = "See Inventory"
+	case KeyCloseDoor:
+		text = "Close door"
+	case KeyShove:
+		text = "Shove monster"
+	case KeyAutoFight:
+		text = "Auto-fight nearest monster"
+	case KeyTravel:
+		text = "Travel to a landmark"
 	}
 	return text
 }
@@ -792,6 +817,8 @@ func (k keyAction) TargetingModeDescription() (text string) {
 		text = "Go to/select target"
 	case KeyExclude:
 		text = "Toggle exclude area from auto-travel"
+	case KeyAnnotate:
+		text = "Annotate position"
 	case KeyEscape:
 		text = "Quit targeting mode"
 	case KeyMenu:
@@ -814,6 +841,7 @@ func (k keyAction) TargetingModeKey() bool {
 		KeyDescription,
 		KeyTarget,
 		KeyExclude,
+		KeyAnnotate,
 		KeyEscape:
 		return true
 	default:
@@ -863,6 +891,10 @@ func ApplyDefaultKeyBindings() {
 		'q': KeyDrink,
 		'd': KeyDrink,
 		'i': KeyInventory,
+		'c': KeyCloseDoor,
+		'p': KeyShove,
+		'F': KeyAutoFight,
+		'T': KeyTravel,
 		't': KeyThrow,
 		'f': KeyThrow,
 		'v': KeyEvoke,
@@ -919,6 +951,7 @@ func ApplyDefaultKeyBindings() {
 		't':    KeyTarget,
 		'f':    KeyTarget,
 		'e':    KeyExclude,
+		'a':    KeyAnnotate,
 		' ':    KeyEscape,
 		'\x1b': KeyEscape,
 		'x':    KeyEscape,
@@ -1001,31 +1034,24 @@ func (ui *gameui) HandleKey(rka runeKeyAction) (err error, again bool, quit bool
 			err = errors.New("No stairs here.")
 		}
 	case KeyGoToStairs:
-		stairs := g.StairsSlice()
-		sortedStairs := g.SortedNearestTo(stairs, g.Player.Pos)
-		if len(sortedStairs) > 0 {
-			stair := sortedStairs[0]
-			if g.Player.Pos == stair {
-				err = errors.New("You are already on the stairs.")
-				break
-			}
-			ex := &examiner{stairs: true}
-			err = ex.Action(g, stair)
-			if err == nil && !g.MoveToTarget(g.Ev) {
-				err = errors.New("You could not move toward stairs.")
-			}
-			if ex.Done() {
-				g.Targeting = InvalidPos
-			}
-		} else {
-			err = errors.New("You cannot go to any stairs.")
-		}
+		err = g.GoToStairs(g.Ev)
 	case KeyEquip:
 		err = g.Equip(g.Ev)
+		err = ui.CleanError(err)
 		ui.MenuSelectedAnimation(MenuInteract, err == nil)
 	case KeyInventory:
 		ui.ViewAll()
 		again = true
+	case KeyCloseDoor:
+		err = g.CloseDoor(g.Ev)
+	case KeyShove:
+		err = g.Shove(g.Ev)
+		err = ui.CleanError(err)
+	case KeyAutoFight:
+		err = g.AutoFight(g.Ev)
+	case KeyTravel:
+		err = ui.SelectLandmark(g.Ev)
+		err = ui.CleanError(err)
 	case KeyDrink:
 		err = ui.SelectPotion(g.Ev)
 		err = ui.CleanError(err)
@@ -1067,7 +1093,9 @@ func (ui *gameui) HandleKey(rka runeKeyAction) (err error, again bool, quit bool
 			g.PrintfStyled("Error: %v", logError, errdump)
 		} else {
 			dataDir, _ := g.DataDir()
-			if dataDir != "" {
+			if dataDir != "" && HTMLDump {
+				g.Printf("Game statistics written to %s (and dump.html).", filepath.Join(dataDir, "dump"))
+			} else if dataDir != "" {
 				g.Printf("Game statistics written to %s.", filepath.Join(dataDir, "dump"))
 			} else {
 				g.Print("Game statistics written.")
@@ -1097,6 +1125,8 @@ func (ui *gameui) HandleKey(rka runeKeyAction) (err error, again bool, quit bool
 		err = fmt.Errorf("You must choose a target to describe.")
 	case KeyExclude:
 		err = fmt.Errorf("You must choose a target for exclusion.")
+	case KeyAnnotate:
+		err = fmt.Errorf("You must choose a position to annotate.")
 	default:
 		err = fmt.Errorf("Unknown key '%c'. Type ? for help.", rka.r)
 	}
@@ -1147,7 +1177,7 @@ func (ui *gameui) NextMonster(r rune, pos position, data *examineData) {
 			nmonster = len(g.Monsters) - 1
 		}
 		mons := g.Monsters[nmonster]
-		if mons.Exists() && g.Player.LOS[mons.Pos] && pos != mons.Pos {
+		if mons.Visible(g) && pos != mons.Pos {
 			pos = mons.Pos
 			break
 		}
@@ -1187,6 +1217,9 @@ func (ui *gameui) NextObject(pos position, data *examineData) {
 		for p := range g.Simellas {
 			data.objects = append(data.objects, p)
 		}
+		for p := range g.Keys {
+			data.objects = append(data.objects, p)
+		}
 		for p := range g.MagicalStones {
 			data.objects = append(data.objects, p)
 		}
@@ -1318,6 +1351,11 @@ func (ui *gameui) CursorKeyAction(targ Targeter, rka runeKeyAction, data *examin
 		ui.SetCursor(pos)
 	case KeyExclude:
 		ui.ExcludeZone(pos)
+	case KeyAnnotate:
+		ui.HideCursor()
+		err = ui.Annotate(pos)
+		err = ui.CleanError(err)
+		ui.SetCursor(pos)
 	case KeyEscape:
 		g.Targeting = InvalidPos
 		notarg = true
@@ -1376,7 +1414,7 @@ func (ui *gameui) CursorAction(targ Targeter, start *position) (err error, again
 	} else {
 		minDist := 999
 		for _, mons := range g.Monsters {
-			if mons.Exists() && g.Player.LOS[mons.Pos] {
+			if mons.Visible(g) {
 				dist := mons.Pos.Distance(g.Player.Pos)
 				if minDist > dist {
 					minDist = dist
@@ -1560,6 +1598,7 @@ type wizardAction int
 const (
 	WizardInfoAction wizardAction = iota
 	WizardToggleMap
+	WizardSpawnBosses
 )
 
 func (a wizardAction) String() (text string) {
@@ -1568,6 +1607,8 @@ func (a wizardAction) String() (text string) {
 		text = "Info"
 	case WizardToggleMap:
 		text = "toggle see/hide monsters"
+	case WizardSpawnBosses:
+		text = "summon bosses"
 	}
 	return text
 }
@@ -1575,6 +1616,7 @@ func (a wizardAction) String() (text string) {
 var wizardActions = []wizardAction{
 	WizardInfoAction,
 	WizardToggleMap,
+	WizardSpawnBosses,
 }
 
 func (ui *gameui) HandleWizardAction() error {
@@ -1589,6 +1631,9 @@ func (ui *gameui) HandleWizardAction() error {
 	case WizardToggleMap:
 		g.WizardMap = !g.WizardMap
 		ui.DrawDungeonView(NoFlushMode)
+	case WizardSpawnBosses:
+		g.WizardSpawnBosses()
+		ui.DrawDungeonView(NoFlushMode)
 	}
 	return nil
 }
@@ -1597,6 +1642,7 @@ func (ui *gameui) Death() {
 	g := ui.g
 	g.Print("You die... [(x) to continue]")
 	ui.DrawDungeonView(NormalMode)
+	ui.MarkFrame(MarkDeath)
 	ui.WaitForContinue(-1)
 	err := g.WriteDump()
 	ui.Dump(err)
@@ -1609,6 +1655,12 @@ func (ui *gameui) Win() {
 	if err != nil {
 		g.PrintfStyled("Error removing save file: %v", logError, err)
 	}
+	if !g.Wizard {
+		err = g.RecordScore(true)
+		if err != nil {
+			g.PrintfStyled("Error writing scores: %v", logError, err)
+		}
+	}
 	if g.Wizard {
 		g.Print("You escape by the magic portal! **WIZARD** [(x) to continue]")
 	} else {
@@ -1632,6 +1684,7 @@ func (ui *gameui) CriticalHPWarning() {
 	g := ui.g
 	g.PrintStyled("*** CRITICAL HP WARNING *** [(x) to continue]", logCritic)
 	ui.DrawDungeonView(NormalMode)
+	ui.MarkFrame(MarkCriticalHP)
 	ui.WaitForContinue(DungeonHeight)
 	g.Print("Ok. Be careful, then.")
 }
@@ -1654,6 +1707,19 @@ func (ui *gameui) Quit() bool {
 	return quit
 }
 
+func (ui *gameui) OfferStairsTravel() bool {
+	g := ui.g
+	if GameConfig.ExploreNoStairs || len(g.StairsSlice()) == 0 {
+		return false
+	}
+	if _, ok := g.Stairs[g.Player.Pos]; ok {
+		return false
+	}
+	g.Print("Travel to the nearest stairs? [y/N]")
+	ui.DrawDungeonView(NormalMode)
+	return ui.PromptConfirmation()
+}
+
 func (ui *gameui) Wizard() bool {
 	g := ui.g
 	g.Print("Do you really want to enter wizard mode (no return)? [y/N]")